@@ -1,5 +1,7 @@
 package ast
 
+import "strings"
+
 type NodeType int
 
 const (
@@ -32,6 +34,19 @@ const (
 	DestructureArrayElementNode
 	DestructureObjectPatternNode
 	DestructureObjectPropertyNode
+	ForLoopNode
+	ForInLoopNode
+	ForOfLoopNode
+	ThrowStmtNode
+	UnaryExprNode
+	UpdateExprNode
+	ConditionalExprNode
+	TemplateLiteralNode
+	SpreadElementNode
+	SwitchStmtNode
+	MatchExprNode
+	DoWhileLoopNode
+	BlockStmtNode
 )
 
 func (n NodeType) String() string {
@@ -90,6 +105,32 @@ func (n NodeType) String() string {
 		return "DestructureObjectPattern"
 	case DestructureObjectPropertyNode:
 		return "DestructureObjectProperties"
+	case ForLoopNode:
+		return "ForLoop"
+	case ForInLoopNode:
+		return "ForInLoop"
+	case ForOfLoopNode:
+		return "ForOfLoop"
+	case ThrowStmtNode:
+		return "ThrowStmt"
+	case UnaryExprNode:
+		return "UnaryExpr"
+	case UpdateExprNode:
+		return "UpdateExpr"
+	case ConditionalExprNode:
+		return "ConditionalExpr"
+	case TemplateLiteralNode:
+		return "TemplateLiteral"
+	case SpreadElementNode:
+		return "SpreadElement"
+	case SwitchStmtNode:
+		return "SwitchStmt"
+	case MatchExprNode:
+		return "MatchExpr"
+	case DoWhileLoopNode:
+		return "DoWhileLoop"
+	case BlockStmtNode:
+		return "BlockStmt"
 	default:
 		return "UnknownNodeType"
 	}
@@ -104,6 +145,8 @@ const (
 	GreaterThan      CompareOperator = ">"
 	Equal            CompareOperator = "=="
 	NotEqual         CompareOperator = "!="
+	InstanceOf       CompareOperator = "instanceof"
+	In               CompareOperator = "in"
 )
 
 type BinaryOperator string
@@ -114,6 +157,57 @@ const (
 	Multiply BinaryOperator = "*"
 	Divide   BinaryOperator = "/"
 	Modulo   BinaryOperator = "%"
+	Exponent BinaryOperator = "**"
+
+	// Bitwise operators work on 32-bit integers, like in JavaScript
+	BitwiseAND BinaryOperator = "&"
+	BitwiseOR  BinaryOperator = "|"
+	BitwiseXOR BinaryOperator = "^"
+	LeftShift  BinaryOperator = "<<"
+	RightShift BinaryOperator = ">>"
+)
+
+type UnaryOperator string
+
+const (
+	UnaryMinus UnaryOperator = "-"
+	UnaryPlus  UnaryOperator = "+"
+	BitwiseNOT UnaryOperator = "~"
+	TypeOf     UnaryOperator = "typeof"
+)
+
+// AssignmentOperator is the operator of a compound assignment, the zero value
+// standing for a plain `=`
+type AssignmentOperator string
+
+const (
+	AddAssign           AssignmentOperator = "+="
+	SubtractAssign      AssignmentOperator = "-="
+	MultiplyAssign      AssignmentOperator = "*="
+	DivideAssign        AssignmentOperator = "/="
+	ModuloAssign        AssignmentOperator = "%="
+	ExponentAssign      AssignmentOperator = "**="
+	BitwiseANDAssign    AssignmentOperator = "&="
+	BitwiseORAssign     AssignmentOperator = "|="
+	BitwiseXORAssign    AssignmentOperator = "^="
+	LeftShiftAssign     AssignmentOperator = "<<="
+	RightShiftAssign    AssignmentOperator = ">>="
+	NilCoalescingAssign AssignmentOperator = "??="
+	LogicalORAssign     AssignmentOperator = "||="
+	LogicalANDAssign    AssignmentOperator = "&&="
+)
+
+// Operator returns the binary or logical operator a compound assignment
+// applies, e.g. "+" for `+=`
+func (o AssignmentOperator) Operator() string {
+	return strings.TrimSuffix(string(o), "=")
+}
+
+type UpdateOperator string
+
+const (
+	Increment UpdateOperator = "++"
+	Decrement UpdateOperator = "--"
 )
 
 type LogicalOperator string
@@ -230,15 +324,43 @@ type Declaration interface {
 type TryCatchStmt struct {
 	Try      []Stmt
 	Catch    []Stmt
-	CatchVar string
+	CatchVar string // Empty when there is no catch clause
+	Finally  []Stmt // Optional finally block
 	SourceMetadata
 }
 
 func (t *TryCatchStmt) GetType() NodeType                 { return TryCatchStmtNode }
 func (t *TryCatchStmt) GetSourceMetadata() SourceMetadata { return t.SourceMetadata }
 
+// Signature is the parameter list of a function or a class method. Defaults
+// and Patterns parallel Params and hold nil for parameters without a default
+// value or a destructuring pattern. A destructured parameter has no name
+type Signature struct {
+	Params   []string
+	Defaults []Expr
+	Patterns []DestructurePattern
+	Rest     *string // Rest parameter collecting the arguments past Params, if any
+}
+
+// Default returns the default value of parameter i, or nil when it has none
+func (s *Signature) Default(i int) Expr {
+	if i < len(s.Defaults) {
+		return s.Defaults[i]
+	}
+	return nil
+}
+
+// Pattern returns the pattern destructuring parameter i, or nil when it has
+// none
+func (s *Signature) Pattern(i int) DestructurePattern {
+	if i < len(s.Patterns) {
+		return s.Patterns[i]
+	}
+	return nil
+}
+
 type FnDeclaration struct {
-	Params    []string
+	Signature
 	Name      string
 	Body      []Stmt
 	Anonymous bool
@@ -261,19 +383,30 @@ func (i *IfStatement) GetSourceMetadata() SourceMetadata { return i.SourceMetada
 
 type Class struct {
 	Name        string
+	Parent      Expr // Optional superclass (`extends`)
 	Body        []Stmt
-	Constructor *ClassMethod
+	Constructor *ClassMethod // nil for subclasses without an explicit constructor
 	SourceMetadata
 }
 
 func (c *Class) GetType() NodeType                 { return ClassNode }
 func (c *Class) GetSourceMetadata() SourceMetadata { return c.SourceMetadata }
 
+// AccessorKind tells getters and setters apart from plain class methods
+type AccessorKind string
+
+const (
+	Getter AccessorKind = "get"
+	Setter AccessorKind = "set"
+)
+
 type ClassMethod struct {
+	Signature
 	Name     string
 	Body     []Stmt
-	Params   []string
 	IsPublic bool
+	IsStatic bool         // Belongs to the class itself rather than its instances
+	Accessor AccessorKind // Set for `get name() {}` and `set name(value) {}`
 	SourceMetadata
 }
 
@@ -284,6 +417,7 @@ type ClassProperty struct {
 	Name     string
 	Value    Expr
 	IsPublic bool
+	IsStatic bool // Belongs to the class itself rather than its instances
 	SourceMetadata
 }
 
@@ -293,12 +427,90 @@ func (c *ClassProperty) GetSourceMetadata() SourceMetadata { return c.SourceMeta
 type WhileLoop struct {
 	Body      []Stmt
 	Condition Expr
+	Label     string // Optional, set by `label: while ...`
 	SourceMetadata
 }
 
 func (w *WhileLoop) GetType() NodeType                 { return WhileLoopNode }
 func (w *WhileLoop) GetSourceMetadata() SourceMetadata { return w.SourceMetadata }
 
+// DoWhileLoop runs its body once before testing Condition, then again for as
+// long as it holds. `continue` jumps to the test
+type DoWhileLoop struct {
+	Body      []Stmt
+	Condition Expr
+	Label     string
+	SourceMetadata
+}
+
+func (d *DoWhileLoop) GetType() NodeType                 { return DoWhileLoopNode }
+func (d *DoWhileLoop) GetSourceMetadata() SourceMetadata { return d.SourceMetadata }
+
+type ForLoop struct {
+	Init      Stmt // Optional, evaluated once in the loop scope
+	Condition Expr // Optional, loops forever when nil
+	Update    Expr // Optional, evaluated after every iteration
+	Body      []Stmt
+	Label     string
+	SourceMetadata
+}
+
+func (f *ForLoop) GetType() NodeType                 { return ForLoopNode }
+func (f *ForLoop) GetSourceMetadata() SourceMetadata { return f.SourceMetadata }
+
+type ForInLoop struct {
+	Constant   bool
+	Identifier string
+	Object     Expr
+	Body       []Stmt
+	Label      string
+	SourceMetadata
+}
+
+func (f *ForInLoop) GetType() NodeType                 { return ForInLoopNode }
+func (f *ForInLoop) GetSourceMetadata() SourceMetadata { return f.SourceMetadata }
+
+type ForOfLoop struct {
+	Constant   bool
+	Identifier string
+	Iterable   Expr
+	Body       []Stmt
+	Label      string
+	SourceMetadata
+}
+
+func (f *ForOfLoop) GetType() NodeType                 { return ForOfLoopNode }
+func (f *ForOfLoop) GetSourceMetadata() SourceMetadata { return f.SourceMetadata }
+
+// SwitchStmt runs the body of the first case listing a value equal to
+// Discriminant, or of the default case when none does. Cases do not fall
+// through, `break` leaves the switch early
+type SwitchStmt struct {
+	Discriminant Expr
+	Cases        []SwitchCase
+	SourceMetadata
+}
+
+func (s *SwitchStmt) GetType() NodeType                 { return SwitchStmtNode }
+func (s *SwitchStmt) GetSourceMetadata() SourceMetadata { return s.SourceMetadata }
+
+// SwitchCase is a case of a switch statement, the default case has no Values
+type SwitchCase struct {
+	Values []Expr
+	Body   []Stmt
+	SourceMetadata
+}
+
+// BlockStmt is a standalone `{ ... }` block, running its body in a scope of
+// its own
+type BlockStmt struct {
+	Body []Stmt
+	SourceMetadata
+}
+
+func (b *BlockStmt) GetType() NodeType                 { return BlockStmtNode }
+func (b *BlockStmt) GetSourceMetadata() SourceMetadata { return b.SourceMetadata }
+
 type ReturnStmt struct {
 	Value Expr
 	SourceMetadata
@@ -307,7 +519,16 @@ type ReturnStmt struct {
 func (r *ReturnStmt) GetType() NodeType                 { return ReturnStmtNode }
 func (r *ReturnStmt) GetSourceMetadata() SourceMetadata { return r.SourceMetadata }
 
+type ThrowStmt struct {
+	Value Expr
+	SourceMetadata
+}
+
+func (t *ThrowStmt) GetType() NodeType                 { return ThrowStmtNode }
+func (t *ThrowStmt) GetSourceMetadata() SourceMetadata { return t.SourceMetadata }
+
 type BreakStmt struct {
+	Label string // Optional, `break label` leaves the loop with that label
 	SourceMetadata
 }
 
@@ -315,6 +536,7 @@ func (r *BreakStmt) GetType() NodeType                 { return BreakStmtNode }
 func (r *BreakStmt) GetSourceMetadata() SourceMetadata { return r.SourceMetadata }
 
 type ContinueStmt struct {
+	Label string // Optional, `continue label` continues the loop with that label
 	SourceMetadata
 }
 
@@ -326,6 +548,7 @@ func (r *ContinueStmt) GetSourceMetadata() SourceMetadata { return r.SourceMetad
 type VarAssignmentExpr struct {
 	Assignee Expr
 	Value    Expr
+	Operator AssignmentOperator // Empty for a plain `=`
 	SourceMetadata
 }
 
@@ -342,6 +565,62 @@ type BinaryExpr struct {
 func (b *BinaryExpr) GetType() NodeType                 { return BinaryExprNode }
 func (b *BinaryExpr) GetSourceMetadata() SourceMetadata { return b.SourceMetadata }
 
+type UnaryExpr struct {
+	Operand  Expr
+	Operator UnaryOperator
+	SourceMetadata
+}
+
+func (u *UnaryExpr) GetType() NodeType                 { return UnaryExprNode }
+func (u *UnaryExpr) GetSourceMetadata() SourceMetadata { return u.SourceMetadata }
+
+// UpdateExpr is an increment or a decrement. The prefix form evaluates to the
+// updated value, the postfix form to the value before the update
+type UpdateExpr struct {
+	Operand  Expr
+	Operator UpdateOperator
+	Prefix   bool
+	SourceMetadata
+}
+
+func (u *UpdateExpr) GetType() NodeType                 { return UpdateExprNode }
+func (u *UpdateExpr) GetSourceMetadata() SourceMetadata { return u.SourceMetadata }
+
+// ConditionalExpr is `Condition ? Consequent : Alternate`, evaluating only
+// the branch taken
+type ConditionalExpr struct {
+	Condition  Expr
+	Consequent Expr
+	Alternate  Expr
+	SourceMetadata
+}
+
+func (c *ConditionalExpr) GetType() NodeType                 { return ConditionalExprNode }
+func (c *ConditionalExpr) GetSourceMetadata() SourceMetadata { return c.SourceMetadata }
+
+// MatchExpr evaluates to the body of the first case matching Subject, or to
+// nil when none does
+type MatchExpr struct {
+	Subject Expr
+	Cases   []MatchCase
+	SourceMetadata
+}
+
+func (m *MatchExpr) GetType() NodeType                 { return MatchExprNode }
+func (m *MatchExpr) GetSourceMetadata() SourceMetadata { return m.SourceMetadata }
+
+// MatchCase is a case of a match expression. It matches when one of its
+// Values equals the subject or, with a Pattern, when the subject has the shape
+// of the pattern, whose variables are then bound for Guard and Body. The
+// default case has neither
+type MatchCase struct {
+	Values  []Expr
+	Pattern DestructurePattern
+	Guard   Expr // Optional, the case only matches when it is truthy
+	Body    Expr
+	SourceMetadata
+}
+
 type CompareExpr struct {
 	LHS      Expr
 	RHS      Expr
@@ -363,8 +642,10 @@ func (l *LogicalExpr) GetType() NodeType                 { return LogicalExprNod
 func (l *LogicalExpr) GetSourceMetadata() SourceMetadata { return l.SourceMetadata }
 
 type CallExpr struct {
-	Args   []Expr
-	Callee Expr
+	Args          []Expr
+	Callee        Expr
+	Optional      bool // `callee?.(args)`
+	Parenthesized bool // `(callee())`, ending any optional chain inside
 	SourceMetadata
 }
 
@@ -372,15 +653,56 @@ func (c *CallExpr) GetType() NodeType                 { return CallExprNode }
 func (c *CallExpr) GetSourceMetadata() SourceMetadata { return c.SourceMetadata }
 
 type MemberExpr struct {
-	Object   Expr
-	Value    Expr
-	Computed bool
+	Object        Expr
+	Value         Expr
+	Computed      bool
+	Optional      bool // `object?.value` or `object?.[value]`
+	Parenthesized bool // `(object.value)`, ending any optional chain inside
 	SourceMetadata
 }
 
 func (m *MemberExpr) GetType() NodeType                 { return MemberExprNode }
 func (m *MemberExpr) GetSourceMetadata() SourceMetadata { return m.SourceMetadata }
 
+// IsOptionalChain reports whether expr is a member or call expression with an
+// optional link `?.` anywhere along its chain. A nil met by an optional link
+// makes the whole chain evaluate to nil. Parentheses end a chain, in
+// `(a?.b).c` only `a?.b` is one
+func IsOptionalChain(expr Expr) bool {
+	for {
+		switch e := expr.(type) {
+		case *MemberExpr:
+			if e.Optional {
+				return true
+			}
+			expr = e.Object
+		case *CallExpr:
+			if e.Optional {
+				return true
+			}
+			expr = e.Callee
+		default:
+			return false
+		}
+		if IsParenthesized(expr) {
+			return false
+		}
+	}
+}
+
+// IsParenthesized reports whether expr is a member or call expression
+// wrapped in parentheses
+func IsParenthesized(expr Expr) bool {
+	switch e := expr.(type) {
+	case *MemberExpr:
+		return e.Parenthesized
+	case *CallExpr:
+		return e.Parenthesized
+	default:
+		return false
+	}
+}
+
 type Identifier struct {
 	Symbol string
 	SourceMetadata
@@ -405,6 +727,21 @@ type StringLiteral struct {
 func (s *StringLiteral) GetType() NodeType                 { return StringLiteralNode }
 func (s *StringLiteral) GetSourceMetadata() SourceMetadata { return s.SourceMetadata }
 
+// TemplateLiteral is a backtick string. Its value is Quasis[0], then each
+// interpolated expression converted to a string followed by the next quasi,
+// so there is always one more quasi than there are expressions
+type TemplateLiteral struct {
+	Quasis []string
+	Exprs  []Expr
+	SourceMetadata
+}
+
+func (t *TemplateLiteral) GetType() NodeType                 { return TemplateLiteralNode }
+func (t *TemplateLiteral) GetSourceMetadata() SourceMetadata { return t.SourceMetadata }
+
+// Property is a property of an object literal. A nil Value is the shorthand
+// `{ key }`, a spread `{ ...value }` has an empty Key and a *SpreadElement
+// Value
 type Property struct {
 	Key   string
 	Value Expr
@@ -422,6 +759,17 @@ type ObjectLiteral struct {
 func (o *ObjectLiteral) GetType() NodeType                 { return ObjectLiteralNode }
 func (o *ObjectLiteral) GetSourceMetadata() SourceMetadata { return o.SourceMetadata }
 
+// SpreadElement is `...Argument`, expanding an array or a string into the
+// arguments of a call or the elements of an array literal, or an object into
+// the properties of an object literal
+type SpreadElement struct {
+	Argument Expr
+	SourceMetadata
+}
+
+func (s *SpreadElement) GetType() NodeType                 { return SpreadElementNode }
+func (s *SpreadElement) GetSourceMetadata() SourceMetadata { return s.SourceMetadata }
+
 type ArrayLiteral struct {
 	Elements []Expr
 	SourceMetadata
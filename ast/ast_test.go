@@ -29,6 +29,10 @@ func TestNodeType_String(t *testing.T) {
 		{ast.IdentifierNode, "Identifier"},
 		{ast.CompareExprNode, "CompareExpr"},
 		{ast.BinaryExprNode, "BinaryExpr"},
+		{ast.ForLoopNode, "ForLoop"},
+		{ast.ForInLoopNode, "ForInLoop"},
+		{ast.ForOfLoopNode, "ForOfLoop"},
+		{ast.ThrowStmtNode, "ThrowStmt"},
 		{ast.NodeType(999), "UnknownNodeType"}, // Test the default case
 	}
 
@@ -90,8 +94,12 @@ func TestStmtGetTypeMethods(t *testing.T) {
 		{"FnDeclaration", &ast.FnDeclaration{}, ast.FnDeclarationNode},
 		{"IfStatement", &ast.IfStatement{}, ast.IfStatementNode},
 		{"WhileLoop", &ast.WhileLoop{}, ast.WhileLoopNode},
+		{"ForLoop", &ast.ForLoop{}, ast.ForLoopNode},
+		{"ForInLoop", &ast.ForInLoop{}, ast.ForInLoopNode},
+		{"ForOfLoop", &ast.ForOfLoop{}, ast.ForOfLoopNode},
 		{"TryCatchStmt", &ast.TryCatchStmt{}, ast.TryCatchStmtNode},
 		{"ReturnStmt", &ast.ReturnStmt{}, ast.ReturnStmtNode},
+		{"ThrowStmt", &ast.ThrowStmt{}, ast.ThrowStmtNode},
 	}
 
 	for _, test := range stmtTests {
@@ -0,0 +1,78 @@
+// Command virtlang runs VirtLang code.
+//
+// Usage:
+//
+//	virtlang                        start an interactive session
+//	virtlang run file.vl [args...]  run a script, exposing args to it as the global `args`
+//
+// The interactive session keeps its variables from one entry to the next:
+//
+//	$ virtlang
+//	> let greet = "hello"
+//	"hello"
+//	> fn shout(s) {
+//	...   return upper(s)
+//	... }
+//	<function shout>
+//	> shout(greet)
+//	"HELLO"
+//
+// `run` exits with status 1 when the script fails to parse or raises an
+// uncaught error, and with status 2 when invoked incorrectly.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+const usage = `Usage:
+  virtlang                        start an interactive session
+  virtlang run file.vl [args...]  run a script
+`
+
+func main() {
+	os.Exit(command(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// command runs the command line args and returns the exit status
+func command(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		if err := repl(stdin, stdout); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		return 0
+	}
+
+	switch args[0] {
+	case "run":
+		if len(args) < 2 {
+			fmt.Fprint(stderr, usage)
+			return 2
+		}
+		return run(args[1], args[2:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "virtlang: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+// report writes a runtime error prefixed with the file and line it was
+// raised at, followed by the frames it carries, innermost first
+func report(w io.Writer, err *errors.RuntimeError) {
+	if err.Line > 0 {
+		fmt.Fprintf(w, "%s:%d: ", err.Filename, err.Line)
+	}
+	fmt.Fprintln(w, err)
+	for i := len(err.Frames) - 1; i >= 0; i-- {
+		frame := err.Frames[i]
+		fmt.Fprintf(w, "    at %s (%s:%d)\n", frame.Name, frame.Filename, frame.Line)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/evaluator"
+	"github.com/dev-kas/virtlang-go/v4/helpers"
+	"github.com/dev-kas/virtlang-go/v4/parser"
+	"github.com/dev-kas/virtlang-go/v4/stdlib"
+)
+
+const (
+	prompt       = "> "
+	continuation = "... "
+)
+
+// repl reads input from in until it ends, evaluating each complete entry in
+// one environment shared by the whole session. Results and errors are written
+// to out; errors are reported without ending the session.
+func repl(in io.Reader, out io.Writer) error {
+	env := environment.NewEnvironment(nil)
+	if err := stdlib.Install(env, out); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "VirtLang REPL, press Ctrl+D to exit.")
+
+	scanner := bufio.NewScanner(in)
+	var entry strings.Builder
+	for {
+		if entry.Len() == 0 {
+			fmt.Fprint(out, prompt)
+		} else {
+			fmt.Fprint(out, continuation)
+		}
+
+		if !scanner.Scan() {
+			// Whatever was left unfinished is evaluated as is, reporting
+			// the syntax error it most likely holds
+			if strings.TrimSpace(entry.String()) != "" {
+				fmt.Fprintln(out)
+				eval(entry.String(), env, out)
+			}
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+
+		entry.WriteString(scanner.Text())
+		entry.WriteString("\n")
+		if incomplete(entry.String()) {
+			continue
+		}
+
+		if strings.TrimSpace(entry.String()) != "" {
+			eval(entry.String(), env, out)
+		}
+		entry.Reset()
+	}
+}
+
+// eval runs one entry, printing its result or the error it raised. Ctrl+C
+// cancels a running entry instead of ending the session.
+func eval(src string, env *environment.Environment, out io.Writer) {
+	program, err := parser.New("<repl>").ProduceAST(src)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, runErr := evaluator.EvaluateWithOptions(program, env, nil, evaluator.Options{Context: ctx})
+	if runErr != nil {
+		report(out, runErr)
+		return
+	}
+	fmt.Fprintln(out, helpers.Inspect(result))
+}
+
+// incomplete reports whether src leaves a bracket, a string or a block
+// comment open, in which case the entry continues on the next line
+func incomplete(src string) bool {
+	depth := 0
+	runes := []rune(src)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && (runes[i] != '*' || runes[i+1] != '/') {
+				i++
+			}
+			if i+1 >= len(runes) {
+				return true
+			}
+			i++
+		case r == '\'' || r == '"' || r == '`':
+			i++
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' {
+					i++ // The escaped rune cannot end the string
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return true
+			}
+		case r == '(' || r == '{' || r == '[':
+			depth++
+		case r == ')' || r == '}' || r == ']':
+			depth--
+		}
+	}
+	return depth > 0
+}
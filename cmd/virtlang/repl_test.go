@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIncomplete(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected bool
+	}{
+		{"let x = 1\n", false},
+		{"fn f() {\n", true},
+		{"fn f() {\n}\n", false},
+		{"let a = [1,\n", true},
+		{"f(1,\n", true},
+		{"let s = \"{\"\n", false},
+		{"let s = 'a\n", true},
+		{"let s = `a\n", true},
+		{"let s = `${[\n1]}`\n", false},
+		{"let s = \"\\\"{\"\n", false},
+		{"let s = \"a\\\\\"\n", false},
+		{"let s = \"a\\\\\\\"\n", true},
+		{"// {\n", false},
+		{"/* {\n", true},
+		{"/* { */\n", false},
+		{"}\n", false},
+	}
+
+	for _, test := range tests {
+		if got := incomplete(test.src); got != test.expected {
+			t.Errorf("incomplete(%q) = %v, expected %v", test.src, got, test.expected)
+		}
+	}
+}
+
+func TestREPL(t *testing.T) {
+	input := strings.Join([]string{
+		"let x = 1",
+		"fn add(a) {",
+		"  return a + x",
+		"}",
+		"add(2)",
+		`"s"`,
+		"y",
+		"let = 1",
+		"print([1, \"a\"])",
+		"x",
+	}, "\n")
+
+	var out strings.Builder
+	if err := repl(strings.NewReader(input), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"1",
+		"<function add>",
+		"3",
+		`"s"`,
+		"Runtime Error: Cannot resolve variable `y`",
+		"Syntax Error",
+		`[1, "a"]`,
+		"> 1\n",
+	}
+	// Each expectation follows the previous one
+	rest := out.String()
+	for _, want := range expected {
+		i := strings.Index(rest, want)
+		if i < 0 {
+			t.Fatalf("expected %q in the remaining output %q", want, rest)
+		}
+		rest = rest[i+len(want):]
+	}
+}
+
+func TestREPLEscapedBackslash(t *testing.T) {
+	input := "\"a\\\\\"\n1 + 1\n"
+
+	var out strings.Builder
+	if err := repl(strings.NewReader(input), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Each entry is evaluated on its own, without a continuation prompt
+	if strings.Contains(out.String(), continuation) {
+		t.Fatalf("expected no continuation prompt, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "> \"a\\\\\"\n> 2\n") {
+		t.Errorf("expected both entries to be evaluated, got %q", out.String())
+	}
+}
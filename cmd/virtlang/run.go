@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/evaluator"
+	"github.com/dev-kas/virtlang-go/v4/parser"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/stdlib"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// run evaluates the script at path with the standard library and the global
+// constant `args` holding scriptArgs. It returns the exit status.
+func run(path string, scriptArgs []string, stdout, stderr io.Writer) int {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "virtlang: %v\n", err)
+		return 1
+	}
+
+	// Parsing with the real filename locates errors and stack frames
+	program, err := parser.New(path).ProduceAST(string(src))
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", path, err)
+		return 1
+	}
+
+	env := environment.NewEnvironment(nil)
+	if err := stdlib.Install(env, stdout); err != nil {
+		report(stderr, err)
+		return 1
+	}
+
+	argValues := make([]shared.RuntimeValue, len(scriptArgs))
+	for i, arg := range scriptArgs {
+		argValues[i] = values.MK_STRING(arg)
+	}
+	if _, err := env.DeclareVar("args", values.MK_ARRAY(argValues), true); err != nil {
+		report(stderr, err)
+		return 1
+	}
+
+	if _, runErr := evaluator.Evaluate(program, env, nil); runErr != nil {
+		report(stderr, runErr)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		src    string
+		args   []string
+		status int
+		stdout string
+		stderr string
+	}{
+		{"print(args)", []string{"a", "b"}, 0, "[\"a\", \"b\"]\n", ""},
+		{"print(len(args))", nil, 0, "0\n", ""},
+		{"fn f(o) {\n  return o.a.b\n}\nf({})", nil, 1, "", "script.vl:2: Runtime Error: Cannot access property"},
+		{"print(1)\nthrow \"oops\"", nil, 1, "1\n", "script.vl:2: Runtime Error: oops"},
+		{"fn f() { f() }\nf()", nil, 1, "", "script.vl:1)\n    at f ("},
+		{"let = 1", nil, 1, "", "script.vl: Syntax Error"},
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "script.vl")
+	for i, test := range tests {
+		if err := os.WriteFile(path, []byte(test.src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var stdout, stderr strings.Builder
+		status := command(append([]string{"run", path}, test.args...), nil, &stdout, &stderr)
+		if status != test.status {
+			t.Errorf("test %d failed: src=%q, expected status %d, got %d (%s)", i, test.src, test.status, status, stderr.String())
+		}
+		if stdout.String() != test.stdout {
+			t.Errorf("test %d failed: src=%q, expected stdout %q, got %q", i, test.src, test.stdout, stdout.String())
+		}
+		if !strings.Contains(stderr.String(), test.stderr) {
+			t.Errorf("test %d failed: src=%q, expected stderr containing %q, got %q", i, test.src, test.stderr, stderr.String())
+		}
+	}
+}
+
+func TestCommandUsage(t *testing.T) {
+	tests := []struct {
+		args   []string
+		status int
+	}{
+		{[]string{"run"}, 2},
+		{[]string{"unknown"}, 2},
+		{[]string{"run", filepath.Join(t.TempDir(), "missing.vl")}, 1},
+		{[]string{"help"}, 0},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr strings.Builder
+		if status := command(test.args, nil, &stdout, &stderr); status != test.status {
+			t.Errorf("args=%q: expected status %d, got %d", test.args, test.status, status)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileBlockStmt(node *ast.BlockStmt) *errors.SyntaxError {
+	c.pushScope(declarations(node.Body))
+	if err := c.compileBody(node.Body, false); err != nil {
+		return err
+	}
+	c.popScope()
+	c.emit(OpNil, 0)
+	return nil
+}
@@ -0,0 +1,49 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileCallExpr(node *ast.CallExpr) *errors.SyntaxError {
+	if ast.IsOptionalChain(node) {
+		return c.compileOptionalChain(node)
+	}
+
+	// Arguments are evaluated before the callee. With spread arguments their
+	// count is only known at runtime, so they are collected into an array
+	spreads := false
+	for _, arg := range node.Args {
+		if _, ok := arg.(*ast.SpreadElement); ok {
+			spreads = true
+		}
+	}
+
+	call, superCall := OpCall, OpSuperCall
+	if spreads {
+		if err := c.compileElements(node.Args); err != nil {
+			return err
+		}
+		call, superCall = OpCallSpread, OpSuperCallSpread
+	} else {
+		for _, arg := range node.Args {
+			if err := c.compile(arg); err != nil {
+				return err
+			}
+		}
+	}
+
+	if callee, ok := node.Callee.(*ast.Identifier); ok && callee.Symbol == "super" {
+		c.emit(superCall, len(node.Args))
+		c.emitData(c.resolve("super"))
+		c.emitData(c.site(node))
+		return nil
+	}
+
+	if err := c.compile(node.Callee); err != nil {
+		return err
+	}
+	c.emit(call, len(node.Args))
+	c.emitData(c.site(node))
+	return nil
+}
@@ -0,0 +1,90 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileClass(node *ast.Class) *errors.SyntaxError {
+	class := &Class{Name: node.Name, Node: node, Extends: node.Parent != nil}
+
+	if node.Parent != nil {
+		if err := c.compile(node.Parent); err != nil {
+			return err
+		}
+	}
+
+	if err := c.compileClassMembers(node, class); err != nil {
+		return err
+	}
+
+	c.fn.Classes = append(c.fn.Classes, class)
+	c.emit(OpClass, len(c.fn.Classes)-1)
+	return c.emitDeclare(node, node.Name, true)
+}
+
+// compileClassMembers compiles the methods, property initializers and
+// constructor of a class. They run in the class scope, an environment shared
+// with the instances, or in the static scope of the class for static members.
+func (c *compiler) compileClassMembers(node *ast.Class, class *Class) *errors.SyntaxError {
+	c.scopes = append(c.scopes, &scope{dynamic: true})
+	defer func() { c.scopes = c.scopes[:len(c.scopes)-1] }()
+
+	for _, stmt := range node.Body {
+		switch member := stmt.(type) {
+		case *ast.ClassMethod:
+			method, err := c.compileFunction(member, member.Name, member.Signature, member.Body, false)
+			if err != nil {
+				return err
+			}
+			class.add(Member{Name: member.Name, IsPublic: member.IsPublic, Method: method, Accessor: member.Accessor}, member.IsStatic)
+
+		case *ast.ClassProperty:
+			property := Member{Name: member.Name, IsPublic: member.IsPublic}
+			if member.Value != nil {
+				value, err := c.compileInitializer(member.Name, member.Value)
+				if err != nil {
+					return err
+				}
+				property.Value = value
+			}
+			class.add(property, member.IsStatic)
+		}
+	}
+
+	if node.Constructor != nil {
+		constructor, err := c.compileFunction(node.Constructor, "constructor", node.Constructor.Signature, node.Constructor.Body, true)
+		if err != nil {
+			return err
+		}
+		class.Constructor = constructor
+	}
+
+	return nil
+}
+
+// compileInitializer compiles the value of a class property. Initializers
+// run directly in the scope of the instance being built.
+func (c *compiler) compileInitializer(name string, value ast.Expr) (*Function, *errors.SyntaxError) {
+	fn := &Function{Name: name, Body: []ast.Stmt{value}}
+	nested := newCompiler(fn, c)
+
+	if err := nested.compile(value); err != nil {
+		return nil, err
+	}
+	nested.emit(OpReturn, 0)
+
+	if nested.overflow {
+		c.overflow = true
+	}
+	return fn, nil
+}
+
+// add appends a compiled member to the instance or static members of a class
+func (class *Class) add(member Member, isStatic bool) {
+	if isStatic {
+		class.Statics = append(class.Statics, member)
+	} else {
+		class.Members = append(class.Members, member)
+	}
+}
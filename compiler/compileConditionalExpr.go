@@ -0,0 +1,25 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileConditionalExpr(node *ast.ConditionalExpr) *errors.SyntaxError {
+	if err := c.compile(node.Condition); err != nil {
+		return err
+	}
+	elseJump := c.emitJump(OpJumpIfFalsy)
+
+	if err := c.compile(node.Consequent); err != nil {
+		return err
+	}
+	endJump := c.emitJump(OpJump)
+	c.patch(elseJump)
+
+	if err := c.compile(node.Alternate); err != nil {
+		return err
+	}
+	c.patch(endJump)
+	return nil
+}
@@ -0,0 +1,90 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+// compileDestructureDeclaration binds a pattern to a value, which is also the
+// value of the declaration
+func (c *compiler) compileDestructureDeclaration(node *ast.DestructureDeclaration) *errors.SyntaxError {
+	if err := c.compile(node.Value); err != nil {
+		return err
+	}
+	c.emit(OpDup, 0)
+	return c.compilePattern(node.Pattern, node.Constant)
+}
+
+// compilePattern binds a pattern to the value on top of the stack, popping it
+func (c *compiler) compilePattern(pattern ast.DestructurePattern, constant bool) *errors.SyntaxError {
+	switch p := pattern.(type) {
+	case *ast.DestructureObjectPattern:
+		c.emit(OpDestructObject, 0)
+
+		keys := make([]string, len(p.Properties))
+		for i, property := range p.Properties {
+			keys[i] = property.Key
+			c.emit(OpDestructProp, c.name(property.Key))
+			if err := c.compileBinding(p, property.Default, property.DeconstructChildren, property.Name, constant); err != nil {
+				return err
+			}
+		}
+
+		if p.Rest != nil {
+			c.emit(OpDestructObjectRest, c.keys(keys))
+			if err := c.emitDeclare(p, *p.Rest, constant); err != nil {
+				return err
+			}
+			c.emit(OpPop, 0)
+		}
+
+	case *ast.DestructureArrayPattern:
+		c.emit(OpDestructArray, 0)
+
+		for i, element := range p.Elements {
+			if element.Skipped {
+				continue
+			}
+			c.emit(OpDestructElem, i)
+			if err := c.compileBinding(p, element.Default, element.DeconstructChildren, element.Name, constant); err != nil {
+				return err
+			}
+		}
+
+		if p.Rest != nil {
+			c.emit(OpDestructArrayRest, len(p.Elements))
+			if err := c.emitDeclare(p, *p.Rest, constant); err != nil {
+				return err
+			}
+			c.emit(OpPop, 0)
+		}
+
+	default:
+		return c.error(pattern, "Unhandled pattern type: %T", p)
+	}
+
+	c.emit(OpPop, 0)
+	return nil
+}
+
+// compileBinding binds the member pushed by OpDestructProp or OpDestructElem,
+// or the argument pushed by OpArg, falling back to its default when it is
+// missing
+func (c *compiler) compileBinding(node ast.Stmt, defaultValue ast.Expr, children ast.DestructurePattern, name string, constant bool) *errors.SyntaxError {
+	present := c.emitJump(OpJumpIfPresent)
+	if defaultValue == nil {
+		c.emit(OpNil, 0)
+	} else if err := c.compile(defaultValue); err != nil {
+		return err
+	}
+	c.patch(present)
+
+	if children != nil {
+		return c.compilePattern(children, constant)
+	}
+	if err := c.emitDeclare(node, name, constant); err != nil {
+		return err
+	}
+	c.emit(OpPop, 0)
+	return nil
+}
@@ -0,0 +1,33 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileDoWhileLoop(node *ast.DoWhileLoop) *errors.SyntaxError {
+	top := len(c.fn.Code)
+	handler := c.emitJump(OpPushLoop)
+	continueTarget := c.emitData(0)
+	c.emitData(c.label(node.Label))
+
+	// Every iteration runs in a scope of its own
+	c.pushScope(declarations(node.Body))
+	if err := c.compileBody(node.Body, false); err != nil {
+		return err
+	}
+	c.popScope()
+	c.emit(OpPopHandler, 0)
+
+	c.patchData(continueTarget)
+	if err := c.compile(node.Condition); err != nil {
+		return err
+	}
+	exitJump := c.emitJump(OpJumpIfNotTrue)
+	c.emit(OpJump, top)
+
+	c.patch(exitJump)
+	c.patch(handler)
+	c.emit(OpNil, 0)
+	return nil
+}
@@ -0,0 +1,120 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileFnDecl(node *ast.FnDeclaration) *errors.SyntaxError {
+	fn, err := c.compileFunction(node, node.Name, node.Signature, node.Body, false)
+	if err != nil {
+		return err
+	}
+
+	c.fn.Functions = append(c.fn.Functions, fn)
+	c.emit(OpClosure, len(c.fn.Functions)-1)
+
+	if node.Anonymous {
+		return nil
+	}
+	return c.emitDeclare(node, node.Name, true)
+}
+
+// compileFunction compiles the body of a function nested in the one being
+// compiled. Calls run in a scope holding the parameters and every variable
+// the body declares.
+func (c *compiler) compileFunction(node ast.Stmt, name string, signature ast.Signature, body []ast.Stmt, isConstructor bool) (*Function, *errors.SyntaxError) {
+	fn := &Function{Name: name, Signature: signature, Body: body, IsConstructor: isConstructor}
+	nested := newCompiler(fn, c)
+
+	// Parameters without defaults or patterns are bound by the call itself,
+	// others by code running before the body
+	prologue := false
+	names := []string{}
+	for i, param := range signature.Params {
+		if signature.Default(i) != nil || signature.Pattern(i) != nil {
+			prologue = true
+		}
+		names = append(names, param)
+		collectDeclarations(signature.Default(i), &names)
+		collectPatternDeclarations(signature.Pattern(i), &names)
+	}
+	if signature.Rest != nil {
+		names = append(names, *signature.Rest)
+	}
+
+	// The call itself enters the scope, no instruction does
+	locals := nested.newSlotScope(append(names, declarations(body)...))
+	nested.scopes = append(nested.scopes, locals)
+	fn.RestSlot = -1
+	if locals.materialized() {
+		fn.Locals = locals.layout()
+		if !prologue {
+			fn.ParamSlots = make([]int, len(signature.Params))
+			for i, param := range signature.Params {
+				fn.ParamSlots[i] = locals.slots[param]
+			}
+			if signature.Rest != nil {
+				fn.RestSlot = locals.slots[*signature.Rest]
+			}
+		}
+	}
+
+	if prologue {
+		if err := nested.compileParams(node, signature); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := nested.compileBody(body, true); err != nil {
+		return nil, err
+	}
+	nested.emit(OpReturnResult, 0)
+
+	if nested.overflow {
+		c.overflow = true
+	}
+	return fn, nil
+}
+
+// compileParams binds the parameters of a function from left to right,
+// missing or nil arguments falling back to their default value. Like the
+// evaluator, the first declaration of a name wins over later parameters
+func (c *compiler) compileParams(node ast.Stmt, signature ast.Signature) *errors.SyntaxError {
+	declared := map[string]bool{}
+
+	for i, param := range signature.Params {
+		pattern := signature.Pattern(i)
+		c.emit(OpArg, i)
+
+		if pattern == nil && declared[param] {
+			// only evaluate the default value
+			present := c.emitJump(OpJumpIfPresent)
+			if defaultValue := signature.Default(i); defaultValue == nil {
+				c.emit(OpNil, 0)
+			} else if err := c.compile(defaultValue); err != nil {
+				return err
+			}
+			c.patch(present)
+			c.emit(OpPop, 0)
+		} else if err := c.compileBinding(node, signature.Default(i), pattern, param, true); err != nil {
+			return err
+		}
+
+		names := []string{param}
+		collectDeclarations(signature.Default(i), &names)
+		collectPatternDeclarations(pattern, &names)
+		for _, name := range names {
+			declared[name] = true
+		}
+	}
+
+	if signature.Rest != nil && !declared[*signature.Rest] {
+		c.emit(OpRestArgs, len(signature.Params))
+		if err := c.emitDeclare(node, *signature.Rest, true); err != nil {
+			return err
+		}
+		c.emit(OpPop, 0)
+	}
+	return nil
+}
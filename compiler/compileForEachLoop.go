@@ -0,0 +1,51 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+// compileForEachLoop compiles `for ... in` and `for ... of` loops, which only
+// differ in the iterator opcode
+func (c *compiler) compileForEachLoop(node ast.Stmt, iterable ast.Expr, identifier string, constant bool, body []ast.Stmt, label string, iterOp Opcode) *errors.SyntaxError {
+	if err := c.compile(iterable); err != nil {
+		return err
+	}
+	c.emit(iterOp, 0)
+
+	top := c.emitJump(OpIterNext)
+
+	// The loop variable and the body share the scope of the iteration
+	iteration := c.pushScope(append([]string{identifier}, declarations(body)...))
+	if err := c.emitDeclare(node, identifier, constant); err != nil {
+		return err
+	}
+	c.emit(OpPop, 0)
+
+	handler := c.emitJump(OpPushLoop)
+	continueTarget := c.emitData(0)
+	c.emitData(c.label(label))
+	if err := c.compileBody(body, false); err != nil {
+		return err
+	}
+	c.emit(OpPopHandler, 0)
+	c.popScope()
+	c.emit(OpJump, top)
+
+	// `continue` and `break` leave the iteration scope too
+	c.patchData(continueTarget)
+	if iteration.materialized() {
+		c.emit(OpPopScope, 0)
+	}
+	c.emit(OpJump, top)
+
+	c.patch(handler)
+	if iteration.materialized() {
+		c.emit(OpPopScope, 0)
+	}
+
+	c.patch(top)
+	c.emit(OpPop, 0)
+	c.emit(OpNil, 0)
+	return nil
+}
@@ -0,0 +1,63 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileForLoop(node *ast.ForLoop) *errors.SyntaxError {
+	// The initializer, condition and update share the loop scope
+	names := []string{}
+	collectDeclarations(node.Init, &names)
+	collectDeclarations(node.Condition, &names)
+	collectDeclarations(node.Update, &names)
+	loop := c.pushScope(names)
+
+	if node.Init != nil {
+		if err := c.compile(node.Init); err != nil {
+			return err
+		}
+		c.emit(OpPop, 0)
+	}
+
+	top := len(c.fn.Code)
+	exitJump := -1
+	if node.Condition != nil {
+		if err := c.compile(node.Condition); err != nil {
+			return err
+		}
+		exitJump = c.emitJump(OpJumpIfNotTrue)
+	}
+
+	handler := c.emitJump(OpPushLoop)
+	continueTarget := c.emitData(0)
+	c.emitData(c.label(node.Label))
+
+	c.pushScope(declarations(node.Body))
+	if err := c.compileBody(node.Body, false); err != nil {
+		return err
+	}
+	c.popScope()
+	c.emit(OpPopHandler, 0)
+
+	c.patchData(continueTarget)
+	// Closures created by an iteration keep that iteration's bindings
+	if loop.materialized() {
+		c.emit(OpForkScope, 0)
+	}
+	if node.Update != nil {
+		if err := c.compile(node.Update); err != nil {
+			return err
+		}
+		c.emit(OpPop, 0)
+	}
+	c.emit(OpJump, top)
+
+	if exitJump >= 0 {
+		c.patch(exitJump)
+	}
+	c.patch(handler)
+	c.popScope()
+	c.emit(OpNil, 0)
+	return nil
+}
@@ -0,0 +1,33 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+// compileIfStmt emits the branches of an if statement, which run in the
+// scope of the statement itself
+func (c *compiler) compileIfStmt(node *ast.IfStatement) *errors.SyntaxError {
+	if err := c.compile(node.Condition); err != nil {
+		return err
+	}
+	elseJump := c.emitJump(OpJumpIfFalsy)
+
+	if err := c.compileBody(node.Body, false); err != nil {
+		return err
+	}
+	endJump := c.emitJump(OpJump)
+	c.patch(elseJump)
+
+	// The parser nests else-if chains, only the first branch is used
+	if len(node.ElseIf) > 0 {
+		if err := c.compileIfStmt(node.ElseIf[0]); err != nil {
+			return err
+		}
+	} else if err := c.compileBody(node.Else, false); err != nil {
+		return err
+	}
+
+	c.patch(endJump)
+	return nil
+}
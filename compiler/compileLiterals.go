@@ -0,0 +1,108 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// compileObjectLiteral builds the object from runs of plain properties, each
+// spread being merged into the object built so far
+func (c *compiler) compileObjectLiteral(node *ast.ObjectLiteral) *errors.SyntaxError {
+	keys := []string{}
+	started := false
+	flush := func() {
+		if !started {
+			c.emit(OpObject, c.keys(keys))
+			started = true
+		} else if len(keys) > 0 {
+			c.emit(OpObject, c.keys(keys))
+			c.emit(OpMerge, 0)
+		}
+		keys = []string{}
+	}
+
+	for _, property := range node.Properties {
+		if spread, ok := property.Value.(*ast.SpreadElement); ok {
+			flush()
+			if err := c.compile(spread.Argument); err != nil {
+				return err
+			}
+			c.emit(OpMerge, 0)
+			continue
+		}
+
+		keys = append(keys, property.Key)
+		if property.Value == nil {
+			// shorthand `{ key }`
+			c.emit(OpGetVar, c.resolve(property.Key))
+		} else if err := c.compile(property.Value); err != nil {
+			return err
+		}
+	}
+
+	flush()
+	return nil
+}
+
+func (c *compiler) compileArrayLiteral(node *ast.ArrayLiteral) *errors.SyntaxError {
+	return c.compileElements(node.Elements)
+}
+
+// compileElements pushes an array of the values of elements, expanding spread
+// elements. Runs of plain elements become arrays appended to the one built so
+// far
+func (c *compiler) compileElements(elements []ast.Expr) *errors.SyntaxError {
+	run := 0
+	started := false
+	flush := func() {
+		if !started {
+			c.emit(OpArray, run)
+			started = true
+		} else if run > 0 {
+			c.emit(OpArray, run)
+			c.emit(OpSpread, 0)
+		}
+		run = 0
+	}
+
+	for _, element := range elements {
+		if spread, ok := element.(*ast.SpreadElement); ok {
+			flush()
+			if err := c.compile(spread.Argument); err != nil {
+				return err
+			}
+			c.emit(OpSpread, 0)
+			continue
+		}
+
+		if err := c.compile(element); err != nil {
+			return err
+		}
+		run++
+	}
+
+	flush()
+	return nil
+}
+
+func (c *compiler) compileTemplateLiteral(node *ast.TemplateLiteral) *errors.SyntaxError {
+	parts := 0
+	for i, quasi := range node.Quasis {
+		if quasi != "" || len(node.Exprs) == 0 {
+			c.emit(OpConstant, c.constant(values.MK_STRING(quasi)))
+			parts++
+		}
+		if i < len(node.Exprs) {
+			if err := c.compile(node.Exprs[i]); err != nil {
+				return err
+			}
+			parts++
+		}
+	}
+
+	if len(node.Exprs) > 0 {
+		c.emit(OpTemplate, parts)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileLogicalExpr(node *ast.LogicalExpr) *errors.SyntaxError {
+	if node.Operator == ast.LogicalNOT {
+		if node.LHS != nil {
+			return c.error(node, "Logical NOT operator can only be used without LHS.")
+		}
+		if err := c.compile(node.RHS); err != nil {
+			return err
+		}
+		c.emit(OpNot, 0)
+		return nil
+	}
+
+	if node.LHS == nil {
+		c.emit(OpNil, 0)
+	} else if err := c.compile(*node.LHS); err != nil {
+		return err
+	}
+
+	var jump int
+	switch node.Operator {
+	case ast.LogicalAND:
+		jump = c.emitJump(OpJumpIfFalsyKeep)
+	case ast.LogicalOR:
+		jump = c.emitJump(OpJumpIfTruthyKeep)
+	case ast.LogicalNilCoalescing:
+		jump = c.emitJump(OpJumpIfNotNilKeep)
+	default:
+		return c.error(node, "Unknown logical operator: %v.", node.Operator)
+	}
+
+	if err := c.compile(node.RHS); err != nil {
+		return err
+	}
+	c.patch(jump)
+	return nil
+}
@@ -0,0 +1,104 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+// compileMatchExpr tries the cases in order, the default case last, keeping
+// the subject on the stack until one of them matches
+func (c *compiler) compileMatchExpr(node *ast.MatchExpr) *errors.SyntaxError {
+	if err := c.compile(node.Subject); err != nil {
+		return err
+	}
+
+	ends := []int{}
+	var defaultCase *ast.MatchCase
+	for i, matchCase := range node.Cases {
+		if len(matchCase.Values) == 0 && matchCase.Pattern == nil {
+			defaultCase = &node.Cases[i]
+			continue
+		}
+		if err := c.compileMatchCase(matchCase, &ends); err != nil {
+			return err
+		}
+	}
+	if defaultCase != nil {
+		if err := c.compileMatchCase(*defaultCase, &ends); err != nil {
+			return err
+		}
+	}
+
+	c.emit(OpPop, 0)
+	c.emit(OpNil, 0)
+	for _, end := range ends {
+		c.patch(end)
+	}
+	return nil
+}
+
+// compileMatchCase emits the test of a case against the subject on top of the
+// stack, falling through when it does not match. A matching case replaces the
+// subject by the value of its body and jumps to the end, recorded in ends
+func (c *compiler) compileMatchCase(node ast.MatchCase, ends *[]int) *errors.SyntaxError {
+	misses := []int{}
+	if node.Pattern != nil {
+		c.emit(OpMatchPattern, len(c.fn.Shapes))
+		c.fn.Shapes = append(c.fn.Shapes, node.Pattern)
+		misses = append(misses, c.emitJump(OpJumpIfFalsy))
+	}
+	if len(node.Values) > 0 {
+		hits, err := c.compileCaseValues(node.Values)
+		if err != nil {
+			return err
+		}
+		misses = append(misses, c.emitJump(OpJump))
+		for _, hit := range hits {
+			c.patch(hit)
+		}
+		c.emit(OpPop, 0)
+	}
+
+	names := []string{}
+	if node.Pattern != nil {
+		collectPatternDeclarations(node.Pattern, &names)
+	}
+	collectDeclarations(node.Guard, &names)
+	collectDeclarations(node.Body, &names)
+	scope := c.pushScope(names)
+
+	if node.Pattern != nil {
+		c.emit(OpDup, 0)
+		if err := c.compilePattern(node.Pattern, false); err != nil {
+			return err
+		}
+	}
+
+	guardMiss := -1
+	if node.Guard != nil {
+		if err := c.compile(node.Guard); err != nil {
+			return err
+		}
+		guardMiss = c.emitJump(OpJumpIfFalsy)
+	}
+
+	if err := c.compile(node.Body); err != nil {
+		return err
+	}
+	c.popScope()
+	c.emit(OpBury, 1)
+	c.emit(OpPop, 0)
+	*ends = append(*ends, c.emitJump(OpJump))
+
+	// A failed guard leaves the scope of the case before trying the next one
+	if guardMiss >= 0 {
+		c.patch(guardMiss)
+		if scope.materialized() {
+			c.emit(OpPopScope, 0)
+		}
+	}
+	for _, miss := range misses {
+		c.patch(miss)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileMemberExpr(node *ast.MemberExpr) *errors.SyntaxError {
+	if ast.IsOptionalChain(node) {
+		return c.compileOptionalChain(node)
+	}
+
+	if err := c.compile(node.Object); err != nil {
+		return err
+	}
+	return c.compileMemberAccess(node)
+}
+
+// compileMemberAccess replaces the object on top of the stack by its member
+func (c *compiler) compileMemberAccess(node *ast.MemberExpr) *errors.SyntaxError {
+	if !node.Computed {
+		c.emit(OpGetMember, c.name(node.Value.(*ast.Identifier).Symbol))
+		c.emitData(c.site(node))
+		return nil
+	}
+
+	if err := c.compile(node.Value); err != nil {
+		return err
+	}
+	c.emit(OpGetIndex, 0)
+	c.emitData(c.site(node))
+	return nil
+}
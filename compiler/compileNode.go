@@ -0,0 +1,151 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// compile emits the code of a node. Once it ran, the code leaves exactly one
+// value on the stack: the value the evaluator returns for the node.
+func (c *compiler) compile(node ast.Stmt) *errors.SyntaxError {
+	// Errors raised by the code are located at the innermost located node,
+	// the way the evaluator locates them
+	if meta := node.GetSourceMetadata(); meta.StartLine != 0 {
+		enclosing := c.position
+		c.position = Site{Filename: meta.Filename, Line: meta.StartLine}
+		defer func() { c.position = enclosing }()
+	}
+
+	switch n := node.(type) {
+	case *ast.NumericLiteral:
+		c.emit(OpConstant, c.constant(values.MK_NUMBER(n.Value)))
+
+	case *ast.StringLiteral:
+		c.emit(OpConstant, c.constant(values.MK_STRING(n.Value)))
+
+	case *ast.TemplateLiteral:
+		return c.compileTemplateLiteral(n)
+
+	case *ast.Identifier:
+		c.emit(OpGetVar, c.resolve(n.Symbol))
+
+	case *ast.ObjectLiteral:
+		return c.compileObjectLiteral(n)
+
+	case *ast.ArrayLiteral:
+		return c.compileArrayLiteral(n)
+
+	case *ast.CallExpr:
+		return c.compileCallExpr(n)
+
+	case *ast.MemberExpr:
+		return c.compileMemberExpr(n)
+
+	case *ast.VarAssignmentExpr:
+		return c.compileVarAssignment(n)
+
+	case *ast.BinaryExpr:
+		if err := c.compile(n.LHS); err != nil {
+			return err
+		}
+		if err := c.compile(n.RHS); err != nil {
+			return err
+		}
+		c.emit(OpBinary, c.name(string(n.Operator)))
+
+	case *ast.UnaryExpr:
+		if err := c.compile(n.Operand); err != nil {
+			return err
+		}
+		c.emit(OpUnary, c.name(string(n.Operator)))
+
+	case *ast.UpdateExpr:
+		return c.compileUpdateExpr(n)
+
+	case *ast.ConditionalExpr:
+		return c.compileConditionalExpr(n)
+
+	case *ast.CompareExpr:
+		if err := c.compile(n.LHS); err != nil {
+			return err
+		}
+		if err := c.compile(n.RHS); err != nil {
+			return err
+		}
+		c.emit(OpCompare, c.name(string(n.Operator)))
+
+	case *ast.LogicalExpr:
+		return c.compileLogicalExpr(n)
+
+	case *ast.VarDeclaration:
+		return c.compileVarDecl(n)
+
+	case *ast.FnDeclaration:
+		return c.compileFnDecl(n)
+
+	case *ast.IfStatement:
+		if err := c.compileIfStmt(n); err != nil {
+			return err
+		}
+		c.emit(OpNil, 0)
+
+	case *ast.WhileLoop:
+		return c.compileWhileLoop(n)
+
+	case *ast.DoWhileLoop:
+		return c.compileDoWhileLoop(n)
+
+	case *ast.ForLoop:
+		return c.compileForLoop(n)
+
+	case *ast.ForInLoop:
+		return c.compileForEachLoop(n, n.Object, n.Identifier, n.Constant, n.Body, n.Label, OpIterKeys)
+
+	case *ast.ForOfLoop:
+		return c.compileForEachLoop(n, n.Iterable, n.Identifier, n.Constant, n.Body, n.Label, OpIterValues)
+
+	case *ast.BlockStmt:
+		return c.compileBlockStmt(n)
+
+	case *ast.SwitchStmt:
+		return c.compileSwitchStmt(n)
+
+	case *ast.MatchExpr:
+		return c.compileMatchExpr(n)
+
+	case *ast.TryCatchStmt:
+		return c.compileTryCatch(n)
+
+	case *ast.ReturnStmt:
+		if n.Value == nil {
+			c.emit(OpNil, 0)
+		} else if err := c.compile(n.Value); err != nil {
+			return err
+		}
+		c.emit(OpReturn, 0)
+
+	case *ast.ThrowStmt:
+		if err := c.compile(n.Value); err != nil {
+			return err
+		}
+		c.emit(OpThrow, 0)
+
+	case *ast.BreakStmt:
+		c.emit(OpBreak, c.label(n.Label))
+
+	case *ast.ContinueStmt:
+		c.emit(OpContinue, c.label(n.Label))
+
+	case *ast.Class:
+		return c.compileClass(n)
+
+	case *ast.DestructureDeclaration:
+		return c.compileDestructureDeclaration(n)
+
+	default:
+		return c.error(node, "Cannot compile node type: %s", node.GetType())
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+// compileOptionalChain compiles a member or call expression containing `?.`.
+// Every optional link jumps to the end of the chain when it meets nil, which
+// is then the value of the chain. Like the evaluator, callees of the chain
+// are evaluated before their arguments
+func (c *compiler) compileOptionalChain(node ast.Expr) *errors.SyntaxError {
+	exits := []int{}
+	if err := c.compileChainLink(node, &exits); err != nil {
+		return err
+	}
+	for _, exit := range exits {
+		c.patch(exit)
+	}
+	return nil
+}
+
+// compileChainObject compiles the object or callee of a link. Parentheses end
+// the chain, so the links they wrap jump to the end of their own chain
+func (c *compiler) compileChainObject(node ast.Expr, exits *[]int) *errors.SyntaxError {
+	if ast.IsParenthesized(node) {
+		return c.compile(node)
+	}
+	return c.compileChainLink(node, exits)
+}
+
+func (c *compiler) compileChainLink(node ast.Expr, exits *[]int) *errors.SyntaxError {
+	switch n := node.(type) {
+	case *ast.MemberExpr:
+		if err := c.compileChainObject(n.Object, exits); err != nil {
+			return err
+		}
+		if n.Optional {
+			*exits = append(*exits, c.emitJump(OpJumpIfNil))
+		}
+		return c.compileMemberAccess(n)
+
+	case *ast.CallExpr:
+		if err := c.compileChainObject(n.Callee, exits); err != nil {
+			return err
+		}
+		if n.Optional {
+			*exits = append(*exits, c.emitJump(OpJumpIfNil))
+		}
+
+		// The arguments go below the callee
+		if err := c.compileElements(n.Args); err != nil {
+			return err
+		}
+		c.emit(OpBury, 1)
+		c.emit(OpCallSpread, len(n.Args))
+		c.emitData(c.site(n))
+		return nil
+
+	default:
+		return c.compile(node)
+	}
+}
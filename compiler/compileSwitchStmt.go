@@ -0,0 +1,85 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+// compileSwitchStmt tests the values of every case against the discriminant
+// first, then lays out the case bodies, each running in a scope of its own
+// under a handler that `break` leaves through
+func (c *compiler) compileSwitchStmt(node *ast.SwitchStmt) *errors.SyntaxError {
+	if err := c.compile(node.Discriminant); err != nil {
+		return err
+	}
+
+	hits := make([][]int, len(node.Cases))
+	for i, switchCase := range node.Cases {
+		var err *errors.SyntaxError
+		if hits[i], err = c.compileCaseValues(switchCase.Values); err != nil {
+			return err
+		}
+	}
+	c.emit(OpPop, 0)
+	missJump := c.emitJump(OpJump)
+
+	ends := []int{}
+	compileCase := func(switchCase ast.SwitchCase) *errors.SyntaxError {
+		ends = append(ends, c.emitJump(OpPushSwitch))
+		c.pushScope(declarations(switchCase.Body))
+		if err := c.compileBody(switchCase.Body, false); err != nil {
+			return err
+		}
+		c.popScope()
+		c.emit(OpPopHandler, 0)
+		ends = append(ends, c.emitJump(OpJump))
+		return nil
+	}
+
+	var defaultCase *ast.SwitchCase
+	for i, switchCase := range node.Cases {
+		if len(switchCase.Values) == 0 {
+			defaultCase = &node.Cases[i]
+			continue
+		}
+
+		// Drop the comparison result and the discriminant
+		for _, hit := range hits[i] {
+			c.patch(hit)
+		}
+		c.emit(OpPop, 0)
+		c.emit(OpPop, 0)
+		if err := compileCase(switchCase); err != nil {
+			return err
+		}
+	}
+
+	c.patch(missJump)
+	if defaultCase != nil {
+		if err := compileCase(*defaultCase); err != nil {
+			return err
+		}
+	}
+
+	for _, end := range ends {
+		c.patch(end)
+	}
+	c.emit(OpNil, 0)
+	return nil
+}
+
+// compileCaseValues compares the value on top of the stack, which is kept,
+// with each value of a case in order. It returns the jumps taken on equality,
+// which leave the result of the comparison on top
+func (c *compiler) compileCaseValues(values []ast.Expr) ([]int, *errors.SyntaxError) {
+	hits := []int{}
+	for _, value := range values {
+		c.emit(OpDup, 0)
+		if err := c.compile(value); err != nil {
+			return nil, err
+		}
+		c.emit(OpCompare, c.name(string(ast.Equal)))
+		hits = append(hits, c.emitJump(OpJumpIfTruthyKeep))
+	}
+	return hits, nil
+}
@@ -0,0 +1,65 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileTryCatch(node *ast.TryCatchStmt) *errors.SyntaxError {
+	finallyHandler := -1
+	if node.Finally != nil {
+		finallyHandler = c.emitJump(OpPushFinally)
+	}
+
+	catchHandler := -1
+	if node.CatchVar != "" {
+		catchHandler = c.emitJump(OpPushCatch)
+	}
+
+	c.pushScope(declarations(node.Try))
+	if err := c.compileBody(node.Try, false); err != nil {
+		return err
+	}
+	c.popScope()
+	c.emit(OpNil, 0)
+
+	if catchHandler >= 0 {
+		c.emit(OpPopHandler, 0)
+		doneJump := c.emitJump(OpJump)
+
+		// The catch block evaluates to its last statement
+		c.patch(catchHandler)
+		c.pushScope(append([]string{node.CatchVar}, declarations(node.Catch)...))
+		c.emit(OpCaught, 0)
+		if err := c.emitDeclare(node, node.CatchVar, false); err != nil {
+			return err
+		}
+		c.emit(OpPop, 0)
+		c.emit(OpNil, 0)
+		for _, stmt := range node.Catch {
+			c.emit(OpPop, 0)
+			if err := c.compile(stmt); err != nil {
+				return err
+			}
+		}
+		c.popScope()
+
+		c.patch(doneJump)
+	}
+
+	if finallyHandler >= 0 {
+		// Completing normally leaves no pending completion, handled ones
+		// enter at the handler target with theirs on the stack
+		c.emit(OpPopHandler, 0)
+		c.emit(OpNil, 0)
+		c.patch(finallyHandler)
+		c.pushScope(declarations(node.Finally))
+		if err := c.compileBody(node.Finally, false); err != nil {
+			return err
+		}
+		c.popScope()
+		c.emit(OpEndFinally, 0)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileUpdateExpr(node *ast.UpdateExpr) *errors.SyntaxError {
+	store, depth, err := c.compileAssignTarget(node.Operand, true)
+	if err != nil {
+		return err
+	}
+
+	if !node.Prefix {
+		// Keep the current value under the target as the result
+		c.emit(OpDup, 0)
+		c.emit(OpBury, depth+1)
+	}
+	c.emit(OpUpdate, c.name(string(node.Operator)))
+	store()
+	if !node.Prefix {
+		c.emit(OpPop, 0)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileVarAssignment(node *ast.VarAssignmentExpr) *errors.SyntaxError {
+	switch node.Assignee.(type) {
+	case *ast.Identifier, *ast.MemberExpr:
+	default:
+		c.emit(OpFail, c.name("Cannot access property of object by non-string key."))
+		return nil
+	}
+
+	if node.Operator == "" {
+		store, _, err := c.compileAssignTarget(node.Assignee, false)
+		if err != nil {
+			return err
+		}
+		if err := c.compile(node.Value); err != nil {
+			return err
+		}
+		store()
+		return nil
+	}
+
+	store, depth, err := c.compileAssignTarget(node.Assignee, true)
+	if err != nil {
+		return err
+	}
+
+	var skip Opcode
+	switch node.Operator {
+	case ast.LogicalANDAssign:
+		skip = OpJumpIfFalsyKeep
+	case ast.LogicalORAssign:
+		skip = OpJumpIfTruthyKeep
+	case ast.NilCoalescingAssign:
+		skip = OpJumpIfNotNilKeep
+	default:
+		if err := c.compile(node.Value); err != nil {
+			return err
+		}
+		c.emit(OpBinary, c.name(node.Operator.Operator()))
+		store()
+		return nil
+	}
+
+	jump := c.emitJump(skip)
+	if err := c.compile(node.Value); err != nil {
+		return err
+	}
+	store()
+	if depth == 0 {
+		c.patch(jump)
+		return nil
+	}
+
+	// Short-circuiting leaves the current value above the target, drop the
+	// target from under it
+	end := c.emitJump(OpJump)
+	c.patch(jump)
+	c.emit(OpBury, depth)
+	for i := 0; i < depth; i++ {
+		c.emit(OpPop, 0)
+	}
+	c.patch(end)
+	return nil
+}
+
+// compileAssignTarget pushes what storing into an identifier or a member
+// expression needs, depth values, followed by its current value when read is
+// set. The returned store pops those and a value on top of them, pushing the
+// stored value.
+func (c *compiler) compileAssignTarget(assignee ast.Expr, read bool) (store func(), depth int, err *errors.SyntaxError) {
+	switch assignee := assignee.(type) {
+	case *ast.Identifier:
+		ref := c.resolve(assignee.Symbol)
+		if read {
+			c.emit(OpGetVar, ref)
+		}
+		return func() { c.emit(OpSetVar, ref) }, 0, nil
+
+	case *ast.MemberExpr:
+		if err := c.compile(assignee.Object); err != nil {
+			return nil, 0, err
+		}
+		c.emit(OpCheckAssignable, 0)
+		site := c.site(assignee)
+
+		if !assignee.Computed {
+			name := c.name(assignee.Value.(*ast.Identifier).Symbol)
+			if read {
+				c.emit(OpPeekMember, name)
+				c.emitData(site)
+			}
+			return func() {
+				c.emit(OpSetMember, name)
+				c.emitData(site)
+			}, 1, nil
+		}
+
+		if err := c.compile(assignee.Value); err != nil {
+			return nil, 0, err
+		}
+		if read {
+			c.emit(OpPeekIndex, 0)
+			c.emitData(site)
+		}
+
+		// Arrays are values, writing to an element of one held by a variable
+		// reassigns the variable
+		ref := 0
+		if object, ok := assignee.Object.(*ast.Identifier); ok {
+			ref = c.resolve(object.Symbol) + 1
+		}
+		return func() {
+			c.emit(OpSetIndex, ref)
+			c.emitData(site)
+		}, 2, nil
+
+	default:
+		return nil, 0, c.error(assignee, "Invalid assignment target: %v.", assignee.GetType())
+	}
+}
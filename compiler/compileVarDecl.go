@@ -0,0 +1,16 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileVarDecl(node *ast.VarDeclaration) *errors.SyntaxError {
+	if node.Value == nil {
+		c.emit(OpNil, 0)
+	} else if err := c.compile(node.Value); err != nil {
+		return err
+	}
+
+	return c.emitDeclare(node, node.Identifier, node.Constant)
+}
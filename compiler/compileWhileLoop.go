@@ -0,0 +1,32 @@
+package compiler
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (c *compiler) compileWhileLoop(node *ast.WhileLoop) *errors.SyntaxError {
+	top := len(c.fn.Code)
+	if err := c.compile(node.Condition); err != nil {
+		return err
+	}
+	exitJump := c.emitJump(OpJumpIfNotTrue)
+
+	handler := c.emitJump(OpPushLoop)
+	c.emitData(top)
+	c.emitData(c.label(node.Label))
+
+	// Every iteration runs in a scope of its own
+	c.pushScope(declarations(node.Body))
+	if err := c.compileBody(node.Body, false); err != nil {
+		return err
+	}
+	c.popScope()
+	c.emit(OpPopHandler, 0)
+	c.emit(OpJump, top)
+
+	c.patch(exitJump)
+	c.patch(handler)
+	c.emit(OpNil, 0)
+	return nil
+}
@@ -0,0 +1,258 @@
+// Package compiler lowers a parsed program to bytecode run by the vm package.
+//
+// The bytecode mirrors the tree-walking evaluator: every place where the
+// evaluator creates an *environment.Environment, the compiled code enters a
+// scope whose variables live in slots resolved at compile time. Scopes that
+// declare nothing are left out entirely. The global scope and class scopes
+// stay environment backed, since the host and class instances need them as
+// environments.
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+)
+
+type compiler struct {
+	fn        *Function
+	enclosing *compiler // Compiler of the function this one is nested in
+	scopes    []*scope  // Scopes of this function, innermost last
+
+	constants map[any]int
+	names     map[string]int
+	overflow  bool // An operand did not fit in an instruction
+	position  Site // Position of the innermost located node being compiled
+}
+
+// scope is the compile time view of a scope.
+type scope struct {
+	dynamic bool           // Environment backed
+	slots   map[string]int // Slot of each variable declared in the scope
+}
+
+// materialized reports whether the scope exists at runtime
+func (s *scope) materialized() bool {
+	return s.dynamic || len(s.slots) > 0
+}
+
+// Compile lowers a program to the bytecode of a function that runs in the
+// global environment handed to the vm.
+func Compile(program *ast.Program) (*Function, *errors.SyntaxError) {
+	c := newCompiler(&Function{Name: "<main>", Body: program.Stmts}, nil)
+	c.scopes = append(c.scopes, &scope{dynamic: true})
+
+	if err := c.compileBody(program.Stmts, true); err != nil {
+		return nil, err
+	}
+	c.emit(OpReturnResult, 0)
+
+	if c.overflow {
+		return nil, c.error(program, "Program is too large to compile.")
+	}
+	return c.fn, nil
+}
+
+func newCompiler(fn *Function, enclosing *compiler) *compiler {
+	return &compiler{
+		fn:        fn,
+		enclosing: enclosing,
+		constants: map[any]int{},
+		names:     map[string]int{},
+	}
+}
+
+func (c *compiler) error(node ast.Stmt, format string, args ...any) *errors.SyntaxError {
+	meta := node.GetSourceMetadata()
+	return &errors.SyntaxError{
+		Message: fmt.Sprintf(format, args...),
+		Start:   errors.Position{Line: meta.StartLine, Col: meta.StartColumn},
+		End:     errors.Position{Line: meta.EndLine, Col: meta.EndColumn},
+	}
+}
+
+// Emission
+
+func (c *compiler) emit(op Opcode, arg int) int {
+	if arg > MaxOperand {
+		c.overflow = true
+	}
+	if n := len(c.fn.Positions); n == 0 || c.fn.Positions[n-1].Site != c.position {
+		c.fn.Positions = append(c.fn.Positions, Position{Offset: len(c.fn.Code), Site: c.position})
+	}
+	c.fn.Code = append(c.fn.Code, MakeInstruction(op, arg))
+	return len(c.fn.Code) - 1
+}
+
+// emitData appends the second operand of the instruction just emitted
+func (c *compiler) emitData(value int) int {
+	c.fn.Code = append(c.fn.Code, Instruction(value))
+	return len(c.fn.Code) - 1
+}
+
+// emitJump emits a jump whose target is patched later
+func (c *compiler) emitJump(op Opcode) int {
+	return c.emit(op, 0)
+}
+
+// patch points the instruction at pos to the next instruction emitted
+func (c *compiler) patch(pos int) {
+	c.patchTo(pos, len(c.fn.Code))
+}
+
+func (c *compiler) patchTo(pos int, target int) {
+	c.fn.Code[pos] = MakeInstruction(c.fn.Code[pos].Op(), target)
+}
+
+// patchData sets the data word at pos to the next instruction emitted
+func (c *compiler) patchData(pos int) {
+	c.fn.Code[pos] = Instruction(len(c.fn.Code))
+}
+
+func (c *compiler) constant(value shared.RuntimeValue) int {
+	if index, ok := c.constants[value.Value]; ok && c.fn.Constants[index].Type == value.Type {
+		return index
+	}
+	c.fn.Constants = append(c.fn.Constants, value)
+	c.constants[value.Value] = len(c.fn.Constants) - 1
+	return len(c.fn.Constants) - 1
+}
+
+func (c *compiler) name(name string) int {
+	if index, ok := c.names[name]; ok {
+		return index
+	}
+	c.fn.Names = append(c.fn.Names, name)
+	c.names[name] = len(c.fn.Names) - 1
+	return len(c.fn.Names) - 1
+}
+
+// label encodes the label of a loop, or of a `break` or `continue` aimed at
+// one, as 0 when there is none and as its index in Names plus one otherwise
+func (c *compiler) label(label string) int {
+	if label == "" {
+		return 0
+	}
+	return c.name(label) + 1
+}
+
+func (c *compiler) site(node ast.Stmt) int {
+	meta := node.GetSourceMetadata()
+	c.fn.Sites = append(c.fn.Sites, Site{Filename: meta.Filename, Line: meta.StartLine})
+	return len(c.fn.Sites) - 1
+}
+
+func (c *compiler) keys(keys []string) int {
+	c.fn.Keys = append(c.fn.Keys, keys)
+	return len(c.fn.Keys) - 1
+}
+
+// Scopes
+
+// pushScope opens a slot scope for the given variables and, when it declares
+// any, emits the instruction entering it at runtime
+func (c *compiler) pushScope(names []string) *scope {
+	s := c.newSlotScope(names)
+	c.scopes = append(c.scopes, s)
+	if s.materialized() {
+		c.fn.Scopes = append(c.fn.Scopes, s.layout())
+		c.emit(OpPushScope, len(c.fn.Scopes)-1)
+	}
+	return s
+}
+
+func (s *scope) layout() *Scope {
+	layout := &Scope{Names: make([]string, len(s.slots))}
+	for name, slot := range s.slots {
+		layout.Names[slot] = name
+	}
+	return layout
+}
+
+// popScope closes the innermost scope, emitting the matching OpPopScope
+func (c *compiler) popScope() {
+	s := c.scopes[len(c.scopes)-1]
+	c.scopes = c.scopes[:len(c.scopes)-1]
+	if s.materialized() {
+		c.emit(OpPopScope, 0)
+	}
+}
+
+func (c *compiler) newSlotScope(names []string) *scope {
+	s := &scope{slots: map[string]int{}}
+	for _, name := range names {
+		if _, ok := s.slots[name]; !ok && name != "" {
+			s.slots[name] = len(s.slots)
+		}
+	}
+	return s
+}
+
+// resolve lists the scopes that may hold a variable, walking out through the
+// scopes of enclosing functions
+func (c *compiler) resolve(name string) int {
+	ref := Ref{Name: name}
+	depth := 0
+	for fc := c; fc != nil; fc = fc.enclosing {
+		for i := len(fc.scopes) - 1; i >= 0; i-- {
+			s := fc.scopes[i]
+			if !s.materialized() {
+				continue
+			}
+			if s.dynamic {
+				ref.Candidates = append(ref.Candidates, Location{Depth: depth, Slot: Dynamic})
+			} else if slot, ok := s.slots[name]; ok {
+				ref.Candidates = append(ref.Candidates, Location{Depth: depth, Slot: slot})
+			}
+			depth++
+		}
+	}
+
+	c.fn.Refs = append(c.fn.Refs, ref)
+	return len(c.fn.Refs) - 1
+}
+
+// emitDeclare declares the value on top of the stack in the current scope
+func (c *compiler) emitDeclare(node ast.Stmt, name string, constant bool) *errors.SyntaxError {
+	// Property initializers have no scope of their own, they run in the
+	// class scope
+	if len(c.scopes) == 0 || c.scopes[len(c.scopes)-1].dynamic || name == "" {
+		// Declaring the empty name only copies the value, any environment
+		// does that
+		if constant {
+			c.emit(OpDeclareNameConst, c.name(name))
+		} else {
+			c.emit(OpDeclareName, c.name(name))
+		}
+		return nil
+	}
+
+	slot, ok := c.scopes[len(c.scopes)-1].slots[name]
+	if !ok {
+		return c.error(node, "Cannot compile declaration of `%s` outside of its scope.", name)
+	}
+	if constant {
+		c.emit(OpDeclareConst, slot)
+	} else {
+		c.emit(OpDeclareVar, slot)
+	}
+	return nil
+}
+
+// compileBody compiles a list of statements. Bodies producing a result keep
+// the value of their last statement, others discard every value
+func (c *compiler) compileBody(stmts []ast.Stmt, result bool) *errors.SyntaxError {
+	for _, stmt := range stmts {
+		if err := c.compile(stmt); err != nil {
+			return err
+		}
+		if result {
+			c.emit(OpSetResult, 0)
+		} else {
+			c.emit(OpPop, 0)
+		}
+	}
+	return nil
+}
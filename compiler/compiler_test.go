@@ -0,0 +1,66 @@
+package compiler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dev-kas/virtlang-go/v4/compiler"
+	"github.com/dev-kas/virtlang-go/v4/internal/testhelpers"
+)
+
+func TestCompileLocals(t *testing.T) {
+	fn, err := compiler.Compile(testhelpers.MustParse(t, "fn f(a) { let b = a\n return b }"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(fn.Functions) != 1 {
+		t.Fatalf("expected 1 nested function, got %d", len(fn.Functions))
+	}
+	f := fn.Functions[0]
+	if f.Locals == nil || strings.Join(f.Locals.Names, ",") != "a,b" {
+		t.Fatalf("expected locals a,b, got %v", f.Locals)
+	}
+
+	expected := `== <main> ==
+0000 CLOSURE 0
+0001 DECLARE_NAME_CONST f
+0002 SET_RESULT
+0003 RETURN_RESULT
+  == f ==
+  0000 GET_VAR a(0:0 1:env)
+  0001 DECLARE_VAR 1
+  0002 SET_RESULT
+  0003 GET_VAR b(0:1 1:env)
+  0004 RETURN
+  0005 SET_RESULT
+  0006 RETURN_RESULT
+`
+	if got := compiler.Disassemble(fn); got != expected {
+		t.Errorf("unexpected bytecode:\n%s", got)
+	}
+}
+
+func TestCompileScopes(t *testing.T) {
+	tests := []struct {
+		input  string
+		scopes int
+	}{
+		// Bodies declaring nothing get no scope
+		{"let i = 0\nwhile (i < 3) { i = i + 1 }", 0},
+		{"let i = 0\nwhile (i < 3) { let j = i\n i = j + 1 }", 1},
+		{"for (let i = 0; i < 3; i = i + 1) { }", 1},
+		{"for (const x of [1]) { let y = x }", 1},
+		{"try { let a = 1 } catch e { } finally { let b = 2 }", 3},
+	}
+
+	for _, test := range tests {
+		fn, err := compiler.Compile(testhelpers.MustParse(t, test.input))
+		if err != nil {
+			t.Fatalf("input=%q: expected no error, got %v", test.input, err)
+		}
+		if len(fn.Scopes) != test.scopes {
+			t.Errorf("input=%q: expected %d scopes, got %d", test.input, test.scopes, len(fn.Scopes))
+		}
+	}
+}
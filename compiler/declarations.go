@@ -0,0 +1,177 @@
+package compiler
+
+import "github.com/dev-kas/virtlang-go/v4/ast"
+
+// declarations lists the variables that evaluating the statements can declare
+// in the scope they run in. Nested scopes (blocks, loop bodies, try blocks,
+// function bodies, class bodies, switch and match cases) are skipped, but
+// `if` bodies share their scope and so do named function expressions, which
+// declare themselves where they appear.
+func declarations(stmts []ast.Stmt) []string {
+	names := []string{}
+	for _, stmt := range stmts {
+		collectDeclarations(stmt, &names)
+	}
+	return names
+}
+
+func collectDeclarations(node ast.Stmt, names *[]string) {
+	if node == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	case *ast.VarDeclaration:
+		*names = append(*names, n.Identifier)
+		collectDeclarations(n.Value, names)
+
+	case *ast.FnDeclaration:
+		*names = append(*names, n.Name)
+
+	case *ast.Class:
+		*names = append(*names, n.Name)
+		collectDeclarations(n.Parent, names)
+
+	case *ast.DestructureDeclaration:
+		collectDeclarations(n.Value, names)
+		collectPatternDeclarations(n.Pattern, names)
+
+	case *ast.IfStatement:
+		collectDeclarations(n.Condition, names)
+		for _, stmt := range n.Body {
+			collectDeclarations(stmt, names)
+		}
+		for _, elseIf := range n.ElseIf {
+			collectDeclarations(elseIf, names)
+		}
+		for _, stmt := range n.Else {
+			collectDeclarations(stmt, names)
+		}
+
+	case *ast.WhileLoop:
+		collectDeclarations(n.Condition, names)
+
+	case *ast.DoWhileLoop:
+		collectDeclarations(n.Condition, names)
+
+	case *ast.ForInLoop:
+		collectDeclarations(n.Object, names)
+
+	case *ast.ForOfLoop:
+		collectDeclarations(n.Iterable, names)
+
+	case *ast.SwitchStmt:
+		collectDeclarations(n.Discriminant, names)
+		for _, switchCase := range n.Cases {
+			for _, value := range switchCase.Values {
+				collectDeclarations(value, names)
+			}
+		}
+
+	case *ast.ReturnStmt:
+		collectDeclarations(n.Value, names)
+
+	case *ast.ThrowStmt:
+		collectDeclarations(n.Value, names)
+
+	case *ast.VarAssignmentExpr:
+		collectDeclarations(n.Assignee, names)
+		collectDeclarations(n.Value, names)
+
+	case *ast.BinaryExpr:
+		collectDeclarations(n.LHS, names)
+		collectDeclarations(n.RHS, names)
+
+	case *ast.UnaryExpr:
+		collectDeclarations(n.Operand, names)
+
+	case *ast.UpdateExpr:
+		collectDeclarations(n.Operand, names)
+
+	case *ast.TemplateLiteral:
+		for _, expr := range n.Exprs {
+			collectDeclarations(expr, names)
+		}
+
+	case *ast.ConditionalExpr:
+		collectDeclarations(n.Condition, names)
+		collectDeclarations(n.Consequent, names)
+		collectDeclarations(n.Alternate, names)
+
+	case *ast.MatchExpr:
+		collectDeclarations(n.Subject, names)
+		for _, matchCase := range n.Cases {
+			for _, value := range matchCase.Values {
+				collectDeclarations(value, names)
+			}
+		}
+
+	case *ast.CompareExpr:
+		collectDeclarations(n.LHS, names)
+		collectDeclarations(n.RHS, names)
+
+	case *ast.LogicalExpr:
+		if n.LHS != nil {
+			collectDeclarations(*n.LHS, names)
+		}
+		collectDeclarations(n.RHS, names)
+
+	case *ast.CallExpr:
+		for _, arg := range n.Args {
+			collectDeclarations(arg, names)
+		}
+		collectDeclarations(n.Callee, names)
+
+	case *ast.MemberExpr:
+		collectDeclarations(n.Object, names)
+		if n.Computed {
+			collectDeclarations(n.Value, names)
+		}
+
+	case *ast.ObjectLiteral:
+		for _, property := range n.Properties {
+			collectDeclarations(property.Value, names)
+		}
+
+	case *ast.ArrayLiteral:
+		for _, element := range n.Elements {
+			collectDeclarations(element, names)
+		}
+
+	case *ast.SpreadElement:
+		collectDeclarations(n.Argument, names)
+	}
+}
+
+func collectPatternDeclarations(pattern ast.DestructurePattern, names *[]string) {
+	switch p := pattern.(type) {
+	case *ast.DestructureObjectPattern:
+		for _, property := range p.Properties {
+			collectDeclarations(property.Default, names)
+			if property.DeconstructChildren != nil {
+				collectPatternDeclarations(property.DeconstructChildren, names)
+			} else {
+				*names = append(*names, property.Name)
+			}
+		}
+		if p.Rest != nil {
+			*names = append(*names, *p.Rest)
+		}
+
+	case *ast.DestructureArrayPattern:
+		for _, element := range p.Elements {
+			if element.Skipped {
+				continue
+			}
+			collectDeclarations(element.Default, names)
+			if element.DeconstructChildren != nil {
+				collectPatternDeclarations(element.DeconstructChildren, names)
+			} else {
+				*names = append(*names, element.Name)
+			}
+		}
+		if p.Rest != nil {
+			*names = append(*names, *p.Rest)
+		}
+	}
+}
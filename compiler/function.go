@@ -0,0 +1,214 @@
+package compiler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+)
+
+// Function is a compiled function body: the program itself, a function
+// declaration, a class method or a class property initializer.
+type Function struct {
+	Name string
+	Body []ast.Stmt // Source of the function, exposed through values.FunctionValue
+	ast.Signature
+
+	Code      []Instruction
+	Constants []shared.RuntimeValue
+	Names     []string   // Member names, operators and names of environment backed variables
+	Keys      [][]string // Key lists of object literals and object rest patterns
+	Refs      []Ref      // Resolved variable references
+	Functions []*Function
+	Classes   []*Class
+	Scopes    []*Scope // Layouts of the block scopes entered with OpPushScope
+	Sites     []Site   // Source positions of calls, reported by stack overflows
+	// Source position of the code from each offset on, by increasing offset,
+	// locating runtime errors
+	Positions []Position
+	// Patterns tested by the cases of match expressions
+	Shapes []ast.DestructurePattern
+
+	// Layout of the scope a call runs in, nil when the function declares
+	// nothing and runs directly in the scope it closes over
+	Locals *Scope
+	// Slot of each parameter in Locals, nil when parameters with default
+	// values or patterns make the code of the function bind them all
+	ParamSlots []int
+	// Slot of the rest parameter in Locals, -1 when the call does not bind it
+	RestSlot int
+
+	IsConstructor bool
+}
+
+// Site is the source position of a call.
+type Site struct {
+	Filename string
+	Line     int
+}
+
+// Position is the source position of the code of a function starting at
+// Offset, that of the innermost node the code was compiled from.
+type Position struct {
+	Offset int
+	Site
+}
+
+// PositionAt returns the source position of the instruction at offset, false
+// when the code there was not compiled from a located node
+func (fn *Function) PositionAt(offset int) (Site, bool) {
+	i := sort.Search(len(fn.Positions), func(i int) bool { return fn.Positions[i].Offset > offset }) - 1
+	if i < 0 || fn.Positions[i].Line == 0 {
+		return Site{}, false
+	}
+	return fn.Positions[i].Site, true
+}
+
+// Scope is the layout of a scope whose variables live in slots.
+type Scope struct {
+	Names []string // Variable name of each slot
+}
+
+// Ref is a variable reference resolved at compile time. Declarations are
+// dynamic (a variable declared in a branch that did not run does not exist),
+// so a reference lists every scope that may hold the variable, innermost
+// first. At runtime the first scope where it is declared wins.
+type Ref struct {
+	Name       string
+	Candidates []Location
+}
+
+// Location is a scope that may hold a variable.
+type Location struct {
+	Depth int // Number of scopes to walk up from the current one
+	Slot  int // Slot in that scope, or Dynamic for an environment backed scope
+}
+
+// Dynamic marks a Location whose scope is an *environment.Environment, where
+// variables are looked up by name: the global scope and class scopes.
+const Dynamic = -1
+
+// Class is a compiled class declaration.
+type Class struct {
+	Name        string
+	Node        *ast.Class
+	Extends     bool
+	Members     []Member  // In declaration order
+	Statics     []Member  // Static members, in declaration order
+	Constructor *Function // nil for subclasses inheriting their parent's
+}
+
+// Member is a method or property of a compiled class.
+type Member struct {
+	Name     string
+	IsPublic bool
+	Method   *Function        // Set for methods
+	Accessor ast.AccessorKind // Set when Method is a getter or setter
+	Value    *Function        // Initializer of a property, nil when it has none
+}
+
+// Disassemble renders the bytecode of a function and of every function nested
+// in it, for debugging and tests.
+func Disassemble(fn *Function) string {
+	var sb strings.Builder
+	disassemble(&sb, fn, "")
+	return sb.String()
+}
+
+func disassemble(sb *strings.Builder, fn *Function, indent string) {
+	name := fn.Name
+	if name == "" {
+		name = "<anonymous>"
+	}
+	fmt.Fprintf(sb, "%s== %s ==\n", indent, name)
+
+	for ip := 0; ip < len(fn.Code); ip++ {
+		ins := fn.Code[ip]
+		op := ins.Op()
+		fmt.Fprintf(sb, "%s%04d %s", indent, ip, op)
+
+		switch op {
+		case OpConstant:
+			fmt.Fprintf(sb, " %v", fn.Constants[ins.Arg()].Value)
+		case OpGetVar, OpSetVar:
+			fmt.Fprintf(sb, " %s", formatRef(fn.Refs[ins.Arg()]))
+		case OpGetMember, OpSetMember, OpPeekMember:
+			ip++
+			fmt.Fprintf(sb, " %s line=%d", fn.Names[ins.Arg()], fn.Sites[fn.Code[ip]].Line)
+		case OpGetIndex, OpPeekIndex:
+			ip++
+			fmt.Fprintf(sb, " line=%d", fn.Sites[fn.Code[ip]].Line)
+		case OpDeclareName, OpDeclareNameConst, OpFail, OpUnary, OpUpdate, OpBinary, OpCompare, OpDestructProp:
+			fmt.Fprintf(sb, " %s", fn.Names[ins.Arg()])
+		case OpObject, OpDestructObjectRest:
+			fmt.Fprintf(sb, " %v", fn.Keys[ins.Arg()])
+		case OpClosure:
+			fmt.Fprintf(sb, " %d", ins.Arg())
+		case OpClass:
+			fmt.Fprintf(sb, " %s", fn.Classes[ins.Arg()].Name)
+		case OpPushScope:
+			fmt.Fprintf(sb, " %v", fn.Scopes[ins.Arg()].Names)
+		case OpPushLoop:
+			ip += 2
+			fmt.Fprintf(sb, " break=%04d continue=%04d", ins.Arg(), int(fn.Code[ip-1]))
+			if label := int(fn.Code[ip]); label > 0 {
+				fmt.Fprintf(sb, " label=%s", fn.Names[label-1])
+			}
+		case OpBreak, OpContinue:
+			if ins.Arg() > 0 {
+				fmt.Fprintf(sb, " %s", fn.Names[ins.Arg()-1])
+			}
+		case OpCall, OpCallSpread:
+			ip++
+			fmt.Fprintf(sb, " %d line=%d", ins.Arg(), fn.Sites[fn.Code[ip]].Line)
+		case OpSuperCall, OpSuperCallSpread:
+			ip += 2
+			fmt.Fprintf(sb, " %d %s line=%d", ins.Arg(), formatRef(fn.Refs[fn.Code[ip-1]]), fn.Sites[fn.Code[ip]].Line)
+		case OpSetIndex:
+			ip++
+			if ins.Arg() > 0 {
+				fmt.Fprintf(sb, " %s", formatRef(fn.Refs[ins.Arg()-1]))
+			}
+			fmt.Fprintf(sb, " line=%d", fn.Sites[fn.Code[ip]].Line)
+		case OpPop, OpDup, OpNil, OpSpread, OpMerge, OpPopScope, OpForkScope, OpNot, OpCheckAssignable,
+			OpReturn, OpThrow, OpSetResult, OpReturnResult, OpPopHandler,
+			OpCaught, OpEndFinally, OpIterKeys, OpIterValues, OpDestructObject, OpDestructArray:
+		default:
+			fmt.Fprintf(sb, " %d", ins.Arg())
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, nested := range fn.Functions {
+		disassemble(sb, nested, indent+"  ")
+	}
+	for _, class := range fn.Classes {
+		for _, members := range [][]Member{class.Members, class.Statics} {
+			for _, member := range members {
+				if member.Method != nil {
+					disassemble(sb, member.Method, indent+"  ")
+				}
+				if member.Value != nil {
+					disassemble(sb, member.Value, indent+"  ")
+				}
+			}
+		}
+		if class.Constructor != nil {
+			disassemble(sb, class.Constructor, indent+"  ")
+		}
+	}
+}
+
+func formatRef(ref Ref) string {
+	locations := make([]string, len(ref.Candidates))
+	for i, location := range ref.Candidates {
+		if location.Slot == Dynamic {
+			locations[i] = fmt.Sprintf("%d:env", location.Depth)
+		} else {
+			locations[i] = fmt.Sprintf("%d:%d", location.Depth, location.Slot)
+		}
+	}
+	return fmt.Sprintf("%s(%s)", ref.Name, strings.Join(locations, " "))
+}
@@ -0,0 +1,215 @@
+package compiler
+
+import "fmt"
+
+type Opcode uint8
+
+const (
+	// Literals
+	OpConstant Opcode = iota // push Constants[arg]
+	OpNil                    // push nil
+	OpArray                  // pop arg values, push an array of them
+	OpObject                 // pop len(Keys[arg]) values, push an object keyed by Keys[arg]
+	OpClosure                // push a function value for Functions[arg]
+	OpClass                  // push a class value for Classes[arg], popping its parent first when it extends one
+	OpTemplate               // pop arg values, push the concatenation of their string forms
+	OpSpread                 // pop an array or a string, append its values to the array on top
+	OpMerge                  // pop a value, copy its properties into the object on top
+
+	// Stack
+	OpPop
+	OpDup
+	OpBury // move the top value below the arg values under it
+
+	// Variables
+	OpGetVar           // push the variable resolved by Refs[arg]
+	OpSetVar           // pop a value, assign it to Refs[arg], push the stored value
+	OpDeclareVar       // pop a value, declare it in slot arg of the current scope, push the stored value
+	OpDeclareConst     // like OpDeclareVar, declaring a constant
+	OpDeclareName      // pop a value, declare Names[arg] in the nearest environment backed scope
+	OpDeclareNameConst // like OpDeclareName, declaring a constant
+
+	// Scopes
+	OpPushScope // enter a new scope laid out by Scopes[arg]
+	OpPopScope  // leave the current scope
+	OpForkScope // replace the current scope with a copy of itself
+
+	// Jumps, arg is the target
+	OpJump
+	OpJumpIfFalsy      // pop a value, jump when it is falsy
+	OpJumpIfNotTrue    // pop a value, jump unless it is the boolean true
+	OpJumpIfFalsyKeep  // jump when the top value is falsy, pop it otherwise
+	OpJumpIfTruthyKeep // jump when the top value is truthy, pop it otherwise
+	OpJumpIfNotNilKeep // jump when the top value is not nil, pop it otherwise
+	OpJumpIfNil        // jump when the top value is nil, keeping the value either way
+
+	// Operators, arg is the operator in Names
+	OpNot
+	OpUnary
+	OpBinary
+	OpCompare
+	OpUpdate // pop a number, push it incremented or decremented
+
+	// Members. Except for OpCheckAssignable, the next word indexes Sites, the
+	// getters and setters of class instances being called from there
+	OpGetMember       // pop an object, push its member Names[arg]
+	OpGetIndex        // pop a key and an object, push the member
+	OpCheckAssignable // fail unless the top value is an object, an array or a class instance
+	OpPeekMember      // push member Names[arg] of the object on top, keeping the object
+	OpPeekIndex       // push the member of the object under the key on top, keeping both
+	OpSetMember       // pop a value and an object, set member Names[arg], push the value
+	OpSetIndex        // pop a value, a key and an object, set the member, push the value. A non-zero arg reassigns Refs[arg-1] to an updated array
+
+	// Calls
+	OpCall      // pop a callee and arg arguments, push the result. The next word indexes Sites
+	OpSuperCall // pop arg arguments and call the parent constructor found through Refs[next word], the word after indexes Sites
+	// Like OpCall and OpSuperCall, popping an array of the arguments instead
+	OpCallSpread
+	OpSuperCallSpread
+	OpArg      // push argument arg of the current call, or a missing marker when it is absent or nil
+	OpRestArgs // push an array of the arguments of the current call from index arg on
+
+	// Control flow
+	OpReturn       // pop a value and return it
+	OpBreak        // leave the innermost loop, or with a non-zero arg the loop labeled Names[arg-1]
+	OpContinue     // skip to the next iteration of the innermost loop, or of the one labeled like OpBreak
+	OpThrow        // pop a value and throw it
+	OpSetResult    // pop a value as the result of the current body
+	OpReturnResult // return the result of the current body
+	OpFail         // raise a runtime error with the message Names[arg]
+
+	// Handlers, arg is the handler target
+	OpPushLoop    // catch `break` (jump to arg) and `continue` (jump to next word), the word after is the label as in OpBreak
+	OpPushSwitch  // catch `break`, letting `continue` through
+	OpPushCatch   // catch errors, letting `return`, `break` and `continue` through
+	OpPushFinally // run the finally block at arg however the protected code is left
+	OpPopHandler  // drop the innermost handler
+	OpCaught      // push the value of the error caught by the last catch handler
+	OpEndFinally  // pop the pending completion of a finally block and resume it
+
+	// Iteration
+	OpIterKeys   // pop a value, push an iterator over its keys
+	OpIterValues // pop a value, push an iterator over its values
+	OpIterNext   // push the next item of the iterator on top, or jump to arg when exhausted
+
+	// Destructuring
+	OpDestructObject     // fail unless the top value is an object
+	OpDestructProp       // push member Names[arg] of the object on top, or a missing marker when absent or nil
+	OpDestructObjectRest // push the members of the object on top not listed in Keys[arg]
+	OpDestructArray      // replace the top value by the array it destructures to
+	OpDestructElem       // push element arg of the array on top, or a missing marker when out of bounds
+	OpDestructArrayRest  // push the elements of the array on top from index arg on
+	OpJumpIfPresent      // jump unless the top value is a missing marker, pop it otherwise
+	OpMatchPattern       // push whether the top value has the shape of Shapes[arg], keeping the value
+)
+
+var opcodeNames = [...]string{
+	OpConstant:           "CONSTANT",
+	OpNil:                "NIL",
+	OpArray:              "ARRAY",
+	OpObject:             "OBJECT",
+	OpClosure:            "CLOSURE",
+	OpClass:              "CLASS",
+	OpTemplate:           "TEMPLATE",
+	OpSpread:             "SPREAD",
+	OpMerge:              "MERGE",
+	OpPop:                "POP",
+	OpDup:                "DUP",
+	OpBury:               "BURY",
+	OpGetVar:             "GET_VAR",
+	OpSetVar:             "SET_VAR",
+	OpDeclareVar:         "DECLARE_VAR",
+	OpDeclareConst:       "DECLARE_CONST",
+	OpDeclareName:        "DECLARE_NAME",
+	OpDeclareNameConst:   "DECLARE_NAME_CONST",
+	OpPushScope:          "PUSH_SCOPE",
+	OpPopScope:           "POP_SCOPE",
+	OpForkScope:          "FORK_SCOPE",
+	OpJump:               "JUMP",
+	OpJumpIfFalsy:        "JUMP_IF_FALSY",
+	OpJumpIfNotTrue:      "JUMP_IF_NOT_TRUE",
+	OpJumpIfFalsyKeep:    "JUMP_IF_FALSY_KEEP",
+	OpJumpIfTruthyKeep:   "JUMP_IF_TRUTHY_KEEP",
+	OpJumpIfNotNilKeep:   "JUMP_IF_NOT_NIL_KEEP",
+	OpJumpIfNil:          "JUMP_IF_NIL",
+	OpNot:                "NOT",
+	OpUnary:              "UNARY",
+	OpBinary:             "BINARY",
+	OpCompare:            "COMPARE",
+	OpUpdate:             "UPDATE",
+	OpGetMember:          "GET_MEMBER",
+	OpGetIndex:           "GET_INDEX",
+	OpCheckAssignable:    "CHECK_ASSIGNABLE",
+	OpPeekMember:         "PEEK_MEMBER",
+	OpPeekIndex:          "PEEK_INDEX",
+	OpSetMember:          "SET_MEMBER",
+	OpSetIndex:           "SET_INDEX",
+	OpCall:               "CALL",
+	OpSuperCall:          "SUPER_CALL",
+	OpCallSpread:         "CALL_SPREAD",
+	OpSuperCallSpread:    "SUPER_CALL_SPREAD",
+	OpArg:                "ARG",
+	OpRestArgs:           "REST_ARGS",
+	OpReturn:             "RETURN",
+	OpBreak:              "BREAK",
+	OpContinue:           "CONTINUE",
+	OpThrow:              "THROW",
+	OpSetResult:          "SET_RESULT",
+	OpReturnResult:       "RETURN_RESULT",
+	OpFail:               "FAIL",
+	OpPushLoop:           "PUSH_LOOP",
+	OpPushSwitch:         "PUSH_SWITCH",
+	OpPushCatch:          "PUSH_CATCH",
+	OpPushFinally:        "PUSH_FINALLY",
+	OpPopHandler:         "POP_HANDLER",
+	OpCaught:             "CAUGHT",
+	OpEndFinally:         "END_FINALLY",
+	OpIterKeys:           "ITER_KEYS",
+	OpIterValues:         "ITER_VALUES",
+	OpIterNext:           "ITER_NEXT",
+	OpDestructObject:     "DESTRUCT_OBJECT",
+	OpDestructProp:       "DESTRUCT_PROP",
+	OpDestructObjectRest: "DESTRUCT_OBJECT_REST",
+	OpDestructArray:      "DESTRUCT_ARRAY",
+	OpDestructElem:       "DESTRUCT_ELEM",
+	OpDestructArrayRest:  "DESTRUCT_ARRAY_REST",
+	OpJumpIfPresent:      "JUMP_IF_PRESENT",
+	OpMatchPattern:       "MATCH_PATTERN",
+}
+
+func (op Opcode) String() string {
+	if int(op) < len(opcodeNames) && opcodeNames[op] != "" {
+		return opcodeNames[op]
+	}
+	return fmt.Sprintf("Opcode(%d)", op)
+}
+
+// dataWords returns the number of extra words following an instruction,
+// holding further operands
+func (op Opcode) dataWords() int {
+	switch op {
+	case OpCall, OpCallSpread, OpGetMember, OpGetIndex, OpPeekMember, OpPeekIndex, OpSetMember, OpSetIndex:
+		return 1
+	case OpPushLoop, OpSuperCall, OpSuperCallSpread:
+		return 2
+	}
+	return 0
+}
+
+// Instruction packs an opcode into the low 8 bits and its operand into the
+// remaining 24 bits.
+type Instruction uint32
+
+const MaxOperand = 1<<24 - 1
+
+func MakeInstruction(op Opcode, arg int) Instruction {
+	return Instruction(uint32(arg)<<8 | uint32(op))
+}
+
+func (i Instruction) Op() Opcode {
+	return Opcode(i & 0xff)
+}
+
+func (i Instruction) Arg() int {
+	return int(i >> 8)
+}
@@ -58,7 +58,13 @@ var Debuggables = map[ast.NodeType]struct{}{
 	ast.VarAssignmentExprNode: {},
 	ast.IfStatementNode:       {},
 	ast.WhileLoopNode:         {},
+	ast.DoWhileLoopNode:       {},
+	ast.ForLoopNode:           {},
+	ast.ForInLoopNode:         {},
+	ast.ForOfLoopNode:         {},
+	ast.SwitchStmtNode:        {},
 	ast.ReturnStmtNode:        {},
+	ast.ThrowStmtNode:         {},
 	ast.ContinueStmtNode:      {},
 	ast.BreakStmtNode:         {},
 	ast.TryCatchStmtNode:      {},
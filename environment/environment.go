@@ -12,6 +12,7 @@ type Environment struct {
 	Parent    *Environment
 	Variables map[string]*shared.RuntimeValue
 	Constants map[string]struct{}
+	Forwards  map[string]*Environment // Names resolved in another environment, see Forward
 	Global    bool
 	Mutex     sync.RWMutex
 }
@@ -56,6 +57,19 @@ func (e *Environment) DeclareVar(name string, value shared.RuntimeValue, constan
 	return e.Variables[name], nil
 }
 
+// Forward makes name resolve in target, as if it were declared here, unless
+// this environment declares a variable of that name itself. Lookups and
+// assignments of the name then share the variable of target.
+func (e *Environment) Forward(name string, target *Environment) {
+	e.Mutex.Lock()
+	defer e.Mutex.Unlock()
+
+	if e.Forwards == nil {
+		e.Forwards = make(map[string]*Environment)
+	}
+	e.Forwards[name] = target
+}
+
 func (e *Environment) Resolve(varname string) (*Environment, *errors.RuntimeError) {
 	e.Mutex.RLock()
 
@@ -64,6 +78,11 @@ func (e *Environment) Resolve(varname string) (*Environment, *errors.RuntimeErro
 		return e, nil
 	}
 
+	if target, forwarded := e.Forwards[varname]; forwarded {
+		e.Mutex.RUnlock()
+		return target.Resolve(varname)
+	}
+
 	if e.Parent == nil {
 		e.Mutex.RUnlock()
 		return nil, &errors.RuntimeError{
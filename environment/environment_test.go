@@ -157,3 +157,34 @@ func TestResolveAndLookupVarWithParent(t *testing.T) {
 		t.Errorf("LookupVar(\"nonExistentVar\") should have panicked")
 	}
 }
+
+func TestForward(t *testing.T) {
+	target := environment.NewEnvironment(nil)
+	target.DeclareVar("shared", shared.RuntimeValue{Type: shared.Number, Value: 1.0}, false)
+	target.DeclareVar("shadowed", shared.RuntimeValue{Type: shared.Number, Value: 2.0}, false)
+
+	parent := environment.NewEnvironment(nil)
+	parent.DeclareVar("outer", shared.RuntimeValue{Type: shared.Number, Value: 3.0}, false)
+
+	env := environment.NewEnvironment(parent)
+	env.Forward("shared", target)
+	env.Forward("shadowed", target)
+	env.DeclareVar("shadowed", shared.RuntimeValue{Type: shared.Number, Value: 4.0}, false)
+
+	if resolved, err := env.Resolve("shared"); err != nil || resolved != target {
+		t.Errorf("expected a forwarded name to resolve in its target, got %v, %v", resolved, err)
+	}
+	if resolved, err := env.Resolve("shadowed"); err != nil || resolved != env {
+		t.Errorf("expected a declared variable to win over a forward, got %v, %v", resolved, err)
+	}
+	if resolved, err := env.Resolve("outer"); err != nil || resolved != parent {
+		t.Errorf("expected other names to resolve through the parent, got %v, %v", resolved, err)
+	}
+
+	if _, err := env.AssignVar("shared", shared.RuntimeValue{Type: shared.Number, Value: 5.0}); err != nil {
+		t.Fatalf("AssignVar(\"shared\") returned error: %v", err)
+	}
+	if value, _ := target.LookupVar("shared"); value.Value != 5.0 {
+		t.Errorf("expected the assignment to reach the target, got %v", value.Value)
+	}
+}
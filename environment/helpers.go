@@ -59,5 +59,13 @@ func DeepCopy(env *Environment) *Environment {
 		(*newEnv).Constants[k] = v
 	}
 
+	// Forwarded names keep resolving in the original environments
+	for k, v := range env.Forwards {
+		if newEnv.Forwards == nil {
+			newEnv.Forwards = make(map[string]*Environment)
+		}
+		newEnv.Forwards[k] = v
+	}
+
 	return newEnv
 }
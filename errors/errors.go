@@ -23,12 +23,54 @@ const (
 type InternalCommunicationProtocol struct {
 	Type   InternalCommunicationProtocolTypes
 	RValue *shared.RuntimeValue
+	Label  string // Loop targeted by a labeled `break` or `continue`, empty for the innermost one
+}
+
+// --- RuntimeErrorKind ---
+// RuntimeErrorKind tells errors raised because an execution limit was hit
+// apart from errors raised by the script itself
+type RuntimeErrorKind int
+
+const (
+	ScriptError       RuntimeErrorKind = iota // Raised by the script or the runtime
+	NodeLimitExceeded                         // The evaluated node budget ran out
+	CallDepthExceeded                         // Calls nested deeper than allowed, scripts can catch it
+	DeadlineExceeded                          // The deadline passed
+	Canceled                                  // The context was canceled
+)
+
+// --- StackFrame ---
+// StackFrame is a call in progress, named after the function called and
+// located at the call site
+type StackFrame struct {
+	Name     string
+	Filename string
+	Line     int
 }
 
 // --- RuntimeError ---
 type RuntimeError struct {
 	Message                       string
 	InternalCommunicationProtocol *InternalCommunicationProtocol
+	Thrown                        *shared.RuntimeValue // Value passed to a script-level `throw`, nil otherwise
+	Kind                          RuntimeErrorKind
+	Frames                        []StackFrame // Innermost calls involved in a stack overflow, outermost first
+	// Location of the innermost node the error was raised at, recorded by
+	// the evaluator. Line is 0 when unknown
+	Filename string
+	Line     int
+}
+
+// IsLimit reports whether the error aborted an execution that hit one of its
+// limits.
+func (e *RuntimeError) IsLimit() bool {
+	return e.Kind != ScriptError
+}
+
+// Catchable reports whether a script-level `catch` may handle the error,
+// which is the case for every error but the limits, stack overflows aside.
+func (e *RuntimeError) Catchable() bool {
+	return e.Kind == ScriptError || e.Kind == CallDepthExceeded
 }
 
 func (e *RuntimeError) Error() string {
@@ -46,6 +46,30 @@ func TestRuntimeError(t *testing.T) {
 	}
 }
 
+func TestRuntimeErrorKind(t *testing.T) {
+	tests := []struct {
+		kind      errors.RuntimeErrorKind
+		limit     bool
+		catchable bool
+	}{
+		{errors.ScriptError, false, true},
+		{errors.NodeLimitExceeded, true, false},
+		{errors.CallDepthExceeded, true, true},
+		{errors.DeadlineExceeded, true, false},
+		{errors.Canceled, true, false},
+	}
+
+	for _, tt := range tests {
+		err := &errors.RuntimeError{Kind: tt.kind}
+		if got := err.IsLimit(); got != tt.limit {
+			t.Errorf("kind %v: IsLimit() = %v, want %v", tt.kind, got, tt.limit)
+		}
+		if got := err.Catchable(); got != tt.catchable {
+			t.Errorf("kind %v: Catchable() = %v, want %v", tt.kind, got, tt.catchable)
+		}
+	}
+}
+
 func TestSyntaxError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,49 @@
+package evaluator
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// bindParams declares the parameters of a function in its call scope, from
+// left to right. Missing or nil arguments fall back to the default value of
+// their parameter, evaluated in the call scope, or to nil. The remaining
+// arguments are collected into the rest parameter, if any
+func bindParams(scope *environment.Environment, signature *ast.Signature, args []*shared.RuntimeValue, dbgr *debugger.Debugger, exec *Budget) *errors.RuntimeError {
+	for i, param := range signature.Params {
+		value := values.MK_NIL()
+		if i < len(args) {
+			value = *args[i]
+		}
+
+		if defaultValue := signature.Default(i); defaultValue != nil && value.Type == shared.Nil {
+			evaluated, err := evaluate(defaultValue, scope, dbgr, exec)
+			if err != nil {
+				return err
+			}
+			value = *evaluated
+		}
+
+		if pattern := signature.Pattern(i); pattern != nil {
+			if err := evalDestructureDeclaration_bindPattern(pattern, &value, scope, true, dbgr, exec); err != nil {
+				return err
+			}
+			continue
+		}
+		scope.DeclareVar(param, value, true)
+	}
+
+	if signature.Rest != nil {
+		remaining := []shared.RuntimeValue{}
+		for i := len(signature.Params); i < len(args); i++ {
+			remaining = append(remaining, *args[i])
+		}
+		scope.DeclareVar(*signature.Rest, values.MK_ARRAY(remaining), true)
+	}
+
+	return nil
+}
@@ -9,17 +9,10 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalArrayExpr(expr *ast.ArrayLiteral, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	elements := expr.Elements
-	results := make([]shared.RuntimeValue, len(elements))
-
-	for index, element := range elements {
-		result, err := Evaluate(element, env, dbgr)
-		if err != nil {
-			return nil, err
-		}
-
-		results[index] = *result
+func evalArrayExpr(expr *ast.ArrayLiteral, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	results, err := evalElements(expr.Elements, env, dbgr, exec)
+	if err != nil {
+		return nil, err
 	}
 
 	result := values.MK_ARRAY(results)
@@ -33,33 +33,41 @@ func countDecimalPlaces(f float64) int {
 	return maxDecimalPlaces
 }
 
-func evalBinEx(binOp *ast.BinaryExpr, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	lhs, err := Evaluate(binOp.LHS, env, dbgr)
+func evalBinEx(binOp *ast.BinaryExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	lhs, err := evaluate(binOp.LHS, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
 
-	rhs, err := Evaluate(binOp.RHS, env, dbgr)
+	rhs, err := evaluate(binOp.RHS, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
 
-	opr := binOp.Operator
-
-	var result *shared.RuntimeValue
+	return BinaryOp(binOp.Operator, lhs, rhs)
+}
 
+// BinaryOp applies an arithmetic operator to two evaluated operands. The vm
+// package shares it so that both backends agree on arithmetic.
+func BinaryOp(opr ast.BinaryOperator, lhs, rhs *shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
 	switch opr {
 	case ast.Plus, ast.Minus:
-		result, err = plusMinus(lhs, rhs, opr == ast.Plus)
+		return plusMinus(lhs, rhs, opr == ast.Plus)
 	case ast.Multiply:
-		result, err = multiply(lhs, rhs)
+		return multiply(lhs, rhs)
 	case ast.Divide:
-		result, err = divide(lhs, rhs)
+		return divide(lhs, rhs)
 	case ast.Modulo:
-		result, err = modulo(lhs, rhs)
+		return modulo(lhs, rhs)
+	case ast.Exponent:
+		return exponent(lhs, rhs)
+	case ast.BitwiseAND, ast.BitwiseOR, ast.BitwiseXOR, ast.LeftShift, ast.RightShift:
+		return bitwise(opr, lhs, rhs)
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Unknown binary operator: %v.", opr),
+		}
 	}
-
-	return result, err
 }
 
 func plusMinus(lhs, rhs *shared.RuntimeValue, isAddition bool) (*shared.RuntimeValue, *errors.RuntimeError) {
@@ -222,3 +230,54 @@ func divide(lhs, rhs *shared.RuntimeValue) (*shared.RuntimeValue, *errors.Runtim
 
 	return &result, nil
 }
+
+func exponent(lhs, rhs *shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if lhs.Type != shared.Number || rhs.Type != shared.Number {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("This binary operation can only be performed on numbers. Attempted to perform exponentiation on `%s` and `%s`", shared.Stringify(lhs.Type), shared.Stringify(rhs.Type)),
+		}
+	}
+
+	result := values.MK_NUMBER(math.Pow(lhs.Value.(float64), rhs.Value.(float64)))
+	return &result, nil
+}
+
+// bitwise applies a bitwise operator the way JavaScript does: both operands
+// are converted to 32-bit integers and shift counts are taken modulo 32
+func bitwise(opr ast.BinaryOperator, lhs, rhs *shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if lhs.Type != shared.Number || rhs.Type != shared.Number {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("This binary operation can only be performed on numbers. Attempted to perform bitwise `%s` on `%s` and `%s`", opr, shared.Stringify(lhs.Type), shared.Stringify(rhs.Type)),
+		}
+	}
+
+	l := toInt32(lhs.Value.(float64))
+	r := toInt32(rhs.Value.(float64))
+
+	var n int32
+	switch opr {
+	case ast.BitwiseAND:
+		n = l & r
+	case ast.BitwiseOR:
+		n = l | r
+	case ast.BitwiseXOR:
+		n = l ^ r
+	case ast.LeftShift:
+		n = l << (uint32(r) & 31)
+	case ast.RightShift:
+		n = l >> (uint32(r) & 31)
+	}
+
+	result := values.MK_NUMBER(float64(n))
+	return &result, nil
+}
+
+// toInt32 converts a number to a 32-bit integer like JavaScript's ToInt32:
+// the fraction is dropped, the integer wraps around modulo 2^32, and NaN and
+// infinities become 0
+func toInt32(f float64) int32 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0
+	}
+	return int32(uint32(int64(math.Mod(math.Trunc(f), 1<<32))))
+}
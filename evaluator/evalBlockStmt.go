@@ -0,0 +1,22 @@
+package evaluator
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+func evalBlockStmt(node *ast.BlockStmt, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	scope := environment.NewEnvironment(env)
+	for _, stmt := range node.Body {
+		if _, err := evaluate(stmt, scope, dbgr, exec); err != nil {
+			return nil, err
+		}
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
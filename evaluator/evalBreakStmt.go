@@ -8,11 +8,12 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalBreakStmt(_ *ast.BreakStmt, _ *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalBreakStmt(node *ast.BreakStmt, _ *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
 	retValue := errors.RuntimeError{
 		Message: "`break` statement used outside of a loop context.",
 		InternalCommunicationProtocol: &errors.InternalCommunicationProtocol{
-			Type: errors.ICP_Break,
+			Type:  errors.ICP_Break,
+			Label: node.Label,
 		},
 	}
 
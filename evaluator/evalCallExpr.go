@@ -11,21 +11,34 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalCallExpr(node *ast.CallExpr, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	args := make([]*shared.RuntimeValue, len(node.Args))
-	for i, arg := range node.Args {
-		evaluatedArg, err := Evaluate(arg, env, dbgr)
-		if err != nil {
-			return nil, err
-		}
-		args[i] = evaluatedArg
+func evalCallExpr(node *ast.CallExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if ast.IsOptionalChain(node) {
+		return evalOptionalChain(node, env, dbgr, exec)
+	}
+
+	evaluatedArgs, err := evalElements(node.Args, env, dbgr, exec)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]*shared.RuntimeValue, len(evaluatedArgs))
+	for i := range evaluatedArgs {
+		args[i] = &evaluatedArgs[i]
+	}
+
+	if callee, ok := node.Callee.(*ast.Identifier); ok && callee.Symbol == "super" {
+		return evalSuperCall(node, args, env, dbgr, exec)
 	}
 
-	fn, err := Evaluate(node.Callee, env, dbgr)
+	fn, err := evaluate(node.Callee, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
 
+	return evalCall(fn, args, node, env, dbgr, exec)
+}
+
+// evalCall calls an already evaluated callee with already evaluated arguments
+func evalCall(fn *shared.RuntimeValue, args []*shared.RuntimeValue, node *ast.CallExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	if fn.Type == shared.NativeFN {
 		nativeFn, err := fn.Value.(values.NativeFunction)
 		if !err {
@@ -49,30 +62,28 @@ func evalCallExpr(node *ast.CallExpr, env *environment.Environment, dbgr *debugg
 				Message: fmt.Sprintf("Expected function value, got %T", fn.Value),
 			}
 		}
+		name := fnVal.Name
+		if name == "" {
+			name = "<anonymous>"
+		}
+		frame := errors.StackFrame{
+			Name:     name,
+			Filename: node.GetSourceMetadata().Filename,
+			Line:     node.GetSourceMetadata().StartLine,
+		}
+		if err := exec.EnterCall(frame); err != nil {
+			return nil, err
+		}
+		defer exec.ExitCall()
+
 		scope := environment.NewEnvironment(fnVal.DeclarationEnv)
+		if err := bindParams(scope, &fnVal.Signature, args, dbgr, exec); err != nil {
+			return nil, err
+		}
 
 		// Push frame to stack
 		if dbgr != nil {
-			dbgr.PushFrame(debugger.StackFrame{
-				Name: func() string {
-					if fnVal.Name == "" {
-						return "<anonymous>"
-					}
-					return fnVal.Name
-				}(),
-				Filename: node.GetSourceMetadata().Filename,
-				Line:     node.GetSourceMetadata().StartLine,
-			})
-		}
-
-		for i, param := range fnVal.Params {
-			var value shared.RuntimeValue
-			if i < len(args) {
-				value = *args[i]
-			} else {
-				value = values.MK_NIL()
-			}
-			scope.DeclareVar(param, value, true)
+			dbgr.PushFrame(debugger.StackFrame(frame))
 		}
 
 		result := values.MK_NIL()
@@ -80,7 +91,7 @@ func evalCallExpr(node *ast.CallExpr, env *environment.Environment, dbgr *debugg
 		for _, stmt := range fnVal.Body {
 			var err *errors.RuntimeError
 			var res *shared.RuntimeValue
-			res, err = Evaluate(stmt, scope, dbgr)
+			res, err = evaluate(stmt, scope, dbgr, exec)
 			if err != nil {
 				// pop frame from stack
 				if dbgr != nil {
@@ -94,7 +105,7 @@ func evalCallExpr(node *ast.CallExpr, env *environment.Environment, dbgr *debugg
 				if dbgr != nil {
 					dbgr.TakeSnapshot()
 				}
-				return nil, err
+				return nil, strayControlFlow(err)
 			}
 			result = *res
 		}
@@ -107,87 +118,17 @@ func evalCallExpr(node *ast.CallExpr, env *environment.Environment, dbgr *debugg
 		return &result, nil
 	} else if fn.Type == shared.Class {
 		classVal := fn.Value.(values.ClassValue)
-		// forkedEnv := environment.NewEnvironment(classVal.DeclarationEnv)
-		// instance := &values.ClassInstanceValue{
-		// 	Class: &classVal,
-		// 	Env:   &forkedEnv,
-		// }
-
-		if classVal.Constructor == nil {
-			return nil, &errors.RuntimeError{
-				Message: "Class has no constructor.",
-			}
-		}
-
-		classScope := environment.NewEnvironment(classVal.DeclarationEnv)
-		publics := map[string]bool{}
-		for _, stmt := range classVal.Body {
-			if stmt.GetType() == ast.ClassMethodNode {
-				method := stmt.(*ast.ClassMethod)
-				_, err := evalClassMethod(method, classScope)
-				if err != nil {
-					return nil, err
-				}
-				if method.IsPublic {
-					publics[method.Name] = true
-				}
-			} else if stmt.GetType() == ast.ClassPropertyNode {
-				property := stmt.(*ast.ClassProperty)
-				_, err := evalClassProperty(property, classScope, dbgr)
-				if err != nil {
-					return nil, err
-				}
-				if property.IsPublic {
-					publics[property.Name] = true
-				}
-			}
-		}
-
-		constructor := classVal.Constructor
-		constructorScope := environment.NewEnvironment(classScope)
-		// Handle all constructor parameters, setting missing ones to nil
-		for i, param := range constructor.Params {
-			var value shared.RuntimeValue
-			if i < len(args) {
-				value = *args[i]
-			} else {
-				value = values.MK_NIL()
-			}
-			constructorScope.DeclareVar(param, value, true)
-		}
 
-		// Push frame to stack
-		if dbgr != nil {
-			dbgr.PushFrame(debugger.StackFrame{
-				Name:     "constructor",
-				Filename: node.GetSourceMetadata().Filename,
-				Line:     node.GetSourceMetadata().StartLine,
-			})
-		}
-
-		for _, stmt := range constructor.Body {
-			_, err := Evaluate(stmt, constructorScope, dbgr)
-			if err != nil {
-				// Take snapshot and pop frame from stack
-				if dbgr != nil {
-					dbgr.TakeSnapshot()
-					dbgr.PopFrame()
-				}
-				if err.InternalCommunicationProtocol != nil && err.InternalCommunicationProtocol.Type == errors.ICP_Return {
-					return nil, &errors.RuntimeError{
-						Message: "Constructor cannot return a value.",
-					}
-				}
-				return nil, err
-			}
+		classScope, publics, accessors, err := buildClassScope(&classVal, dbgr, exec)
+		if err != nil {
+			return nil, err
 		}
 
-		// Pop frame from stack
-		if dbgr != nil {
-			dbgr.PopFrame()
+		if err := runConstructor(&classVal, classScope, args, node, dbgr, exec); err != nil {
+			return nil, err
 		}
 
-		retVal := values.MK_CLASS_INSTANCE(&classVal, publics, classScope)
+		retVal := instanceWithAccessors(values.MK_CLASS_INSTANCE(&classVal, publics, classScope), accessors)
 		return &retVal, nil
 	} else {
 		return nil, &errors.RuntimeError{
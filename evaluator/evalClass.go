@@ -1,15 +1,83 @@
 package evaluator
 
 import (
+	"fmt"
+
 	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
 	"github.com/dev-kas/virtlang-go/v4/environment"
 	"github.com/dev-kas/virtlang-go/v4/errors"
 	"github.com/dev-kas/virtlang-go/v4/shared"
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalClass(node *ast.Class, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalClass(node *ast.Class, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	class := values.MK_CLASS(node.Name, node.Body, node.Constructor, env)
 
+	if node.Parent != nil {
+		parent, err := evaluate(node.Parent, env, dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent.Type != shared.Class {
+			return nil, &errors.RuntimeError{
+				Message: fmt.Sprintf("Class `%s` cannot extend a non-class (attempted to extend a %s).", node.Name, shared.Stringify(parent.Type)),
+			}
+		}
+
+		parentVal := parent.Value.(values.ClassValue)
+		classVal := class.Value.(values.ClassValue)
+		classVal.Parent = &parentVal
+		class.Value = classVal
+	}
+
+	classVal := class.Value.(values.ClassValue)
+	if err := buildStatics(&classVal, dbgr, exec); err != nil {
+		return nil, err
+	}
+	class.Value = classVal
+
 	return env.DeclareVar(node.Name, class, true)
 }
+
+// buildStatics evaluates the static members of a class into a scope of their
+// own, on top of the scope the class was declared in. Inherited static
+// members resolve in the parent's static scope, the same way inherited
+// instance members do.
+func buildStatics(classVal *values.ClassValue, dbgr *debugger.Debugger, exec *Budget) *errors.RuntimeError {
+	statics := environment.NewEnvironment(classVal.DeclarationEnv)
+	publics := map[string]bool{}
+
+	if classVal.Parent != nil && classVal.Parent.Statics != nil {
+		for name, isPublic := range classVal.Parent.StaticPublics {
+			publics[name] = isPublic
+			statics.Forward(name, classVal.Parent.Statics)
+		}
+	}
+
+	for _, stmt := range classVal.Body {
+		switch member := stmt.(type) {
+		case *ast.ClassMethod:
+			if !member.IsStatic {
+				continue
+			}
+			if _, err := evalClassMethod(member, statics); err != nil {
+				return err
+			}
+			publics[member.Name] = member.IsPublic
+		case *ast.ClassProperty:
+			if !member.IsStatic {
+				continue
+			}
+			if _, err := evalClassProperty(member, statics, dbgr, exec); err != nil {
+				return err
+			}
+			publics[member.Name] = member.IsPublic
+		}
+	}
+
+	classVal.Statics = statics
+	classVal.StaticPublics = publics
+	return nil
+}
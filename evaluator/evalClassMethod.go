@@ -9,24 +9,29 @@ import (
 )
 
 func evalClassMethod(node *ast.ClassMethod, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	rtValue := classMethodValue(node, env)
+
+	_, err := env.DeclareVar(node.Name, rtValue, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rtValue, nil
+}
+
+// classMethodValue creates the function value of a method closing over env
+func classMethodValue(node *ast.ClassMethod, env *environment.Environment) shared.RuntimeValue {
 	method := &values.FunctionValue{
 		Name:           node.Name,
-		Params:         node.Params,
+		Signature:      node.Signature,
 		DeclarationEnv: env,
 		Body:           node.Body,
 		Type:           shared.Function,
 		Value:          nil,
 	}
 
-	rtValue := &shared.RuntimeValue{
+	return shared.RuntimeValue{
 		Type:  shared.Function,
 		Value: method,
 	}
-
-	_, err := env.DeclareVar(node.Name, *rtValue, false)
-	if err != nil {
-		return nil, err
-	}
-
-	return rtValue, nil
 }
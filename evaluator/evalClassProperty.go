@@ -9,14 +9,14 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalClassProperty(node *ast.ClassProperty, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalClassProperty(node *ast.ClassProperty, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	propertyName := node.Name
 	propertyValue := node.Value
 
 	var value shared.RuntimeValue = values.MK_NIL()
 
 	if propertyValue != nil {
-		val, err := Evaluate(propertyValue, env, dbgr)
+		val, err := evaluate(propertyValue, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/dev-kas/virtlang-go/v4/ast"
 	"github.com/dev-kas/virtlang-go/v4/debugger"
@@ -157,18 +158,78 @@ func compareGreater(lhs, rhs *shared.RuntimeValue, orEqual bool) (*shared.Runtim
 	return &res, nil
 }
 
-func evalComEx(expression *ast.CompareExpr, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	lhs, err := Evaluate(expression.LHS, env, dbgr)
+// compareInstanceOf handles instanceof, true for instances of the class or of
+// a class extending it
+func compareInstanceOf(lhs, rhs *shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if rhs.Type != shared.Class {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("The right-hand side of `instanceof` must be a class, got %s.", shared.Stringify(rhs.Type)),
+		}
+	}
+
+	result := false
+	if lhs.Type == shared.ClassInstance {
+		target := rhs.Value.(values.ClassValue)
+		instance := lhs.Value.(values.ClassInstanceValue)
+		for class := &instance.Class; class != nil && !result; class = class.Parent {
+			result = class.ID == target.ID
+		}
+	}
+
+	res := values.MK_BOOL(result)
+	return &res, nil
+}
+
+// compareIn handles in, checking for a key of an object, an index of an array
+// or a public member of a class instance
+func compareIn(lhs, rhs *shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
+	result := false
+
+	switch rhs.Type {
+	case shared.Object:
+		if lhs.Type == shared.String {
+			_, result = rhs.Value.(map[string]*shared.RuntimeValue)[lhs.Value.(string)]
+		}
+
+	case shared.Array:
+		if lhs.Type == shared.Number {
+			index := lhs.Value.(float64)
+			result = index >= 0 && index == math.Trunc(index) && index < float64(len(rhs.Value.([]shared.RuntimeValue)))
+		}
+
+	case shared.ClassInstance:
+		if lhs.Type == shared.String {
+			result = rhs.Value.(values.ClassInstanceValue).Publics[lhs.Value.(string)]
+		}
+
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot use `in` to look for a key in a %s (only objects, arrays and class instances have keys).", shared.Stringify(rhs.Type)),
+		}
+	}
+
+	res := values.MK_BOOL(result)
+	return &res, nil
+}
+
+func evalComEx(expression *ast.CompareExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	lhs, err := evaluate(expression.LHS, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
 
-	rhs, err := Evaluate(expression.RHS, env, dbgr)
+	rhs, err := evaluate(expression.RHS, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
 
-	switch expression.Operator {
+	return CompareOp(expression.Operator, lhs, rhs)
+}
+
+// CompareOp applies a comparison operator to two evaluated operands. The vm
+// package shares it so that both backends agree on comparisons.
+func CompareOp(operator ast.CompareOperator, lhs, rhs *shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
+	switch operator {
 	case ast.Equal:
 		return compareEqual(lhs, rhs, false)
 	case ast.NotEqual:
@@ -181,9 +242,13 @@ func evalComEx(expression *ast.CompareExpr, env *environment.Environment, dbgr *
 		return compareGreater(lhs, rhs, false)
 	case ast.GreaterThanEqual:
 		return compareGreater(lhs, rhs, true)
+	case ast.InstanceOf:
+		return compareInstanceOf(lhs, rhs)
+	case ast.In:
+		return compareIn(lhs, rhs)
 	default:
 		return nil, &errors.RuntimeError{
-			Message: fmt.Sprintf("Unknown comparison operator: %v.", expression.Operator),
+			Message: fmt.Sprintf("Unknown comparison operator: %v.", operator),
 		}
 	}
 }
@@ -0,0 +1,22 @@
+package evaluator
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/helpers"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+)
+
+func evalConditionalExpr(node *ast.ConditionalExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	cond, err := evaluate(node.Condition, env, dbgr, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	if helpers.IsTruthy(cond) {
+		return evaluate(node.Consequent, env, dbgr, exec)
+	}
+	return evaluate(node.Alternate, env, dbgr, exec)
+}
@@ -8,11 +8,12 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalContinueStmt(_ *ast.ContinueStmt, _ *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalContinueStmt(node *ast.ContinueStmt, _ *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
 	retValue := errors.RuntimeError{
 		Message: "`continue` statement used outside of a loop context.",
 		InternalCommunicationProtocol: &errors.InternalCommunicationProtocol{
-			Type: errors.ICP_Continue,
+			Type:  errors.ICP_Continue,
+			Label: node.Label,
 		},
 	}
 
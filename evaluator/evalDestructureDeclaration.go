@@ -11,13 +11,13 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalDestructureDeclaration(node *ast.DestructureDeclaration, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	rhsValue, err := Evaluate(node.Value, env, dbgr)
+func evalDestructureDeclaration(node *ast.DestructureDeclaration, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	rhsValue, err := evaluate(node.Value, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
 
-	err = evalDestructureDeclaration_bindPattern(node.Pattern, rhsValue, env, node.Constant, dbgr)
+	err = evalDestructureDeclaration_bindPattern(node.Pattern, rhsValue, env, node.Constant, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +26,7 @@ func evalDestructureDeclaration(node *ast.DestructureDeclaration, env *environme
 }
 
 // recursive helper function
-func evalDestructureDeclaration_bindPattern(pattern ast.DestructurePattern, value *shared.RuntimeValue, env *environment.Environment, isConstant bool, dbgr *debugger.Debugger) *errors.RuntimeError {
+func evalDestructureDeclaration_bindPattern(pattern ast.DestructurePattern, value *shared.RuntimeValue, env *environment.Environment, isConstant bool, dbgr *debugger.Debugger, exec *Budget) *errors.RuntimeError {
 	switch p := pattern.(type) {
 	case *ast.DestructureObjectPattern:
 		// ensure we're destructuring an object
@@ -45,7 +45,7 @@ func evalDestructureDeclaration_bindPattern(pattern ast.DestructurePattern, valu
 			// default values if key doesnt exist
 			if !exists || subValue.Type == shared.Nil {
 				if prop.Default != nil {
-					evaluatedDefault, err := Evaluate(prop.Default, env, dbgr)
+					evaluatedDefault, err := evaluate(prop.Default, env, dbgr, exec)
 					if err != nil {
 						return err
 					}
@@ -58,7 +58,7 @@ func evalDestructureDeclaration_bindPattern(pattern ast.DestructurePattern, valu
 
 			if prop.DeconstructChildren != nil {
 				// we have a nested pattern
-				err := evalDestructureDeclaration_bindPattern(prop.DeconstructChildren, subValue, env, isConstant, dbgr)
+				err := evalDestructureDeclaration_bindPattern(prop.DeconstructChildren, subValue, env, isConstant, dbgr, exec)
 				if err != nil {
 					return err
 				}
@@ -125,7 +125,7 @@ func evalDestructureDeclaration_bindPattern(pattern ast.DestructurePattern, valu
 			if i >= len(arrValue) {
 				// out of bounds: use default value or nil
 				if element.Default != nil {
-					evaluatedDefault, err := Evaluate(element.Default, env, dbgr)
+					evaluatedDefault, err := evaluate(element.Default, env, dbgr, exec)
 					if err != nil {
 						return err
 					}
@@ -140,7 +140,7 @@ func evalDestructureDeclaration_bindPattern(pattern ast.DestructurePattern, valu
 			}
 
 			if element.DeconstructChildren != nil {
-				err := evalDestructureDeclaration_bindPattern(element.DeconstructChildren, subValue, env, isConstant, dbgr)
+				err := evalDestructureDeclaration_bindPattern(element.DeconstructChildren, subValue, env, isConstant, dbgr, exec)
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,35 @@
+package evaluator
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// evalDoWhileLoop runs the body before every test of the condition, which
+// like in `while` loops has to be the boolean true to go on
+func evalDoWhileLoop(astNode *ast.DoWhileLoop, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	for {
+		broke, err := evalLoopBody(astNode.Body, astNode.Label, environment.NewEnvironment(env), dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+		if broke {
+			break
+		}
+
+		cond, err := evaluate(astNode.Condition, env, dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+		if cond.Type != shared.Boolean || !cond.Value.(bool) {
+			break
+		}
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
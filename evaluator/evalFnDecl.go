@@ -11,7 +11,7 @@ import (
 func evalFnDecl(node *ast.FnDeclaration, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
 	fn := &values.FunctionValue{
 		Name:           node.Name,
-		Params:         node.Params,
+		Signature:      node.Signature,
 		DeclarationEnv: env,
 		Body:           node.Body,
 		Type:           shared.Function,
@@ -0,0 +1,90 @@
+package evaluator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// IterationKeys lists the keys visited by `for ... in`: object keys and
+// public class instance members in sorted order, indices for arrays and strings
+func IterationKeys(value *shared.RuntimeValue) ([]shared.RuntimeValue, *errors.RuntimeError) {
+	keys := []shared.RuntimeValue{}
+
+	switch value.Type {
+	case shared.Object:
+		names := []string{}
+		for name := range value.Value.(map[string]*shared.RuntimeValue) {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			keys = append(keys, values.MK_STRING(name))
+		}
+
+	case shared.ClassInstance:
+		names := []string{}
+		for name, isPublic := range value.Value.(values.ClassInstanceValue).Publics {
+			if isPublic {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			keys = append(keys, values.MK_STRING(name))
+		}
+
+	case shared.Array:
+		for i := range value.Value.([]shared.RuntimeValue) {
+			keys = append(keys, values.MK_NUMBER(float64(i)))
+		}
+
+	case shared.String:
+		for i := range []rune(value.Value.(string)) {
+			keys = append(keys, values.MK_NUMBER(float64(i)))
+		}
+
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot iterate over the keys of a %s.", shared.Stringify(value.Type)),
+		}
+	}
+
+	return keys, nil
+}
+
+func evalForInLoop(astNode *ast.ForInLoop, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	obj, err := evaluate(astNode.Object, env, dbgr, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := IterationKeys(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		scope := environment.NewEnvironment(env)
+		if _, err := scope.DeclareVar(astNode.Identifier, key, astNode.Constant); err != nil {
+			return nil, err
+		}
+
+		broke, err := evalLoopBody(astNode.Body, astNode.Label, scope, dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+		if broke {
+			break
+		}
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
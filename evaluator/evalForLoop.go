@@ -0,0 +1,93 @@
+package evaluator
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// evalLoopBody runs a single iteration of the body of the loop with the given
+// label, reporting whether the loop was left with `break`
+func evalLoopBody(body []ast.Stmt, label string, scope *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (bool, *errors.RuntimeError) {
+	if err := exec.Interrupted(); err != nil {
+		return false, err
+	}
+
+	for _, stmt := range body {
+		_, err := evaluate(stmt, scope, dbgr, exec)
+		if err != nil {
+			switch {
+			case isControlFlowFor(err, errors.ICP_Continue, label):
+				return false, nil
+			case isControlFlowFor(err, errors.ICP_Break, label):
+				return true, nil
+			default:
+				return false, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// forkIterationScope copies the bindings of a loop scope into a fresh one,
+// so closures created during an iteration keep that iteration's values
+func forkIterationScope(scope *environment.Environment) *environment.Environment {
+	next := environment.NewEnvironment(scope.Parent)
+
+	scope.Mutex.RLock()
+	defer scope.Mutex.RUnlock()
+
+	for name, value := range scope.Variables {
+		val := *value
+		next.Variables[name] = &val
+	}
+	for name := range scope.Constants {
+		next.Constants[name] = struct{}{}
+	}
+
+	return next
+}
+
+func evalForLoop(astNode *ast.ForLoop, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	scope := environment.NewEnvironment(env)
+
+	if astNode.Init != nil {
+		if _, err := evaluate(astNode.Init, scope, dbgr, exec); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		if astNode.Condition != nil {
+			cond, err := evaluate(astNode.Condition, scope, dbgr, exec)
+			if err != nil {
+				return nil, err
+			}
+			// Like while loops, only the boolean true keeps the loop going
+			if cond.Type != shared.Boolean || !cond.Value.(bool) {
+				break
+			}
+		}
+
+		broke, err := evalLoopBody(astNode.Body, astNode.Label, environment.NewEnvironment(scope), dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+		if broke {
+			break
+		}
+
+		scope = forkIterationScope(scope)
+		if astNode.Update != nil {
+			if _, err := evaluate(astNode.Update, scope, dbgr, exec); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
@@ -0,0 +1,67 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// IterationValues lists the values visited by `for ... of`: array
+// elements, or the characters of a string
+func IterationValues(value *shared.RuntimeValue) ([]shared.RuntimeValue, *errors.RuntimeError) {
+	switch value.Type {
+	case shared.Array:
+		// iterate over a copy so that the body can modify the array freely
+		elements := value.Value.([]shared.RuntimeValue)
+		items := make([]shared.RuntimeValue, len(elements))
+		copy(items, elements)
+		return items, nil
+
+	case shared.String:
+		items := []shared.RuntimeValue{}
+		for _, char := range value.Value.(string) {
+			items = append(items, values.MK_STRING(string(char)))
+		}
+		return items, nil
+
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot iterate over a %s (only arrays and strings are iterable).", shared.Stringify(value.Type)),
+		}
+	}
+}
+
+func evalForOfLoop(astNode *ast.ForOfLoop, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	iterable, err := evaluate(astNode.Iterable, env, dbgr, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := IterationValues(iterable)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		scope := environment.NewEnvironment(env)
+		if _, err := scope.DeclareVar(astNode.Identifier, item, astNode.Constant); err != nil {
+			return nil, err
+		}
+
+		broke, err := evalLoopBody(astNode.Body, astNode.Label, scope, dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+		if broke {
+			break
+		}
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
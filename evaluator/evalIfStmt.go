@@ -10,8 +10,8 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalIfStmt(statement *ast.IfStatement, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	cond, err := Evaluate(statement.Condition, env, dbgr)
+func evalIfStmt(statement *ast.IfStatement, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	cond, err := evaluate(statement.Condition, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
@@ -19,7 +19,7 @@ func evalIfStmt(statement *ast.IfStatement, env *environment.Environment, dbgr *
 	// Main `if` branch
 	if helpers.IsTruthy(cond) {
 		for _, stmt := range statement.Body {
-			if _, err := Evaluate(stmt, env, dbgr); err != nil {
+			if _, err := evaluate(stmt, env, dbgr, exec); err != nil {
 				return nil, err
 			}
 		}
@@ -34,12 +34,12 @@ func evalIfStmt(statement *ast.IfStatement, env *environment.Environment, dbgr *
 		// elements in array, but just one array that's deeply nested
 		// and i thought it;s already a good option,
 		// so we just evaluate the first element
-		return evalIfStmt(statement.ElseIf[0], env, dbgr)
+		return evalIfStmt(statement.ElseIf[0], env, dbgr, exec)
 	}
 
 	// Else branch
 	for _, stmt := range statement.Else {
-		if _, err := Evaluate(stmt, env, dbgr); err != nil {
+		if _, err := evaluate(stmt, env, dbgr, exec); err != nil {
 			return nil, err
 		}
 	}
@@ -12,7 +12,7 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalLogicEx(expression *ast.LogicalExpr, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalLogicEx(expression *ast.LogicalExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	var lhs *shared.RuntimeValue
 	var err *errors.RuntimeError
 
@@ -23,7 +23,7 @@ func evalLogicEx(expression *ast.LogicalExpr, env *environment.Environment, dbgr
 			}
 		}
 
-		rhs, err := Evaluate(expression.RHS, env, dbgr)
+		rhs, err := evaluate(expression.RHS, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
@@ -33,7 +33,7 @@ func evalLogicEx(expression *ast.LogicalExpr, env *environment.Environment, dbgr
 	}
 
 	if expression.LHS != nil {
-		lhs, err = Evaluate(*expression.LHS, env, dbgr)
+		lhs, err = evaluate(*expression.LHS, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
@@ -44,7 +44,7 @@ func evalLogicEx(expression *ast.LogicalExpr, env *environment.Environment, dbgr
 		if !helpers.IsTruthy(lhs) {
 			return lhs, nil // short circuit: return if false
 		}
-		rhs, err := Evaluate(expression.RHS, env, dbgr)
+		rhs, err := evaluate(expression.RHS, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
@@ -54,7 +54,7 @@ func evalLogicEx(expression *ast.LogicalExpr, env *environment.Environment, dbgr
 		if helpers.IsTruthy(lhs) {
 			return lhs, nil // short circuit: return if true
 		}
-		rhs, err := Evaluate(expression.RHS, env, dbgr)
+		rhs, err := evaluate(expression.RHS, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
@@ -62,7 +62,7 @@ func evalLogicEx(expression *ast.LogicalExpr, env *environment.Environment, dbgr
 
 	case ast.LogicalNilCoalescing:
 		if lhs == nil || (lhs.Type == shared.Nil && lhs.Value == nil) {
-			rhs, err := Evaluate(expression.RHS, env, dbgr)
+			rhs, err := evaluate(expression.RHS, env, dbgr, exec)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,129 @@
+package evaluator
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/helpers"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// evalMatchExpr evaluates the body of the first case matching the subject,
+// trying the default case last. Nothing matching evaluates to nil
+func evalMatchExpr(node *ast.MatchExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	subject, err := evaluate(node.Subject, env, dbgr, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultCase *ast.MatchCase
+	for i := range node.Cases {
+		matchCase := &node.Cases[i]
+		if len(matchCase.Values) == 0 && matchCase.Pattern == nil {
+			defaultCase = matchCase
+			continue
+		}
+
+		if result, err := evalMatchCase(matchCase, subject, env, dbgr, exec); result != nil || err != nil {
+			return result, err
+		}
+	}
+
+	if defaultCase != nil {
+		if result, err := evalMatchCase(defaultCase, subject, env, dbgr, exec); result != nil || err != nil {
+			return result, err
+		}
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
+
+// evalMatchCase evaluates the body of a case in a scope of its own, holding
+// the variables bound by its pattern. It returns nil when the case does not
+// match the subject
+func evalMatchCase(node *ast.MatchCase, subject *shared.RuntimeValue, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if node.Pattern != nil && !PatternMatches(node.Pattern, subject) {
+		return nil, nil
+	}
+	if len(node.Values) > 0 {
+		found, err := listsValue(node.Values, subject, env, dbgr, exec)
+		if err != nil || !found {
+			return nil, err
+		}
+	}
+
+	scope := environment.NewEnvironment(env)
+	if node.Pattern != nil {
+		if err := evalDestructureDeclaration_bindPattern(node.Pattern, subject, scope, false, dbgr, exec); err != nil {
+			return nil, err
+		}
+	}
+
+	if node.Guard != nil {
+		guard, err := evaluate(node.Guard, scope, dbgr, exec)
+		if err != nil || !helpers.IsTruthy(guard) {
+			return nil, err
+		}
+	}
+
+	return evaluate(node.Body, scope, dbgr, exec)
+}
+
+// PatternMatches reports whether value has the shape of a destructuring
+// pattern: an object holding every key listed without a default, or an array
+// with an element for every position without a default and, unless the
+// pattern has a rest element, no more elements than positions. Nested
+// patterns must match the values they destructure
+func PatternMatches(pattern ast.DestructurePattern, value *shared.RuntimeValue) bool {
+	switch p := pattern.(type) {
+	case *ast.DestructureObjectPattern:
+		if value.Type != shared.Object {
+			return false
+		}
+		object := value.Value.(map[string]*shared.RuntimeValue)
+
+		for _, property := range p.Properties {
+			member, exists := object[property.Key]
+			if !exists || member.Type == shared.Nil {
+				if property.Default == nil {
+					return false
+				}
+				continue
+			}
+			if property.DeconstructChildren != nil && !PatternMatches(property.DeconstructChildren, member) {
+				return false
+			}
+		}
+		return true
+
+	case *ast.DestructureArrayPattern:
+		if value.Type != shared.Array {
+			return false
+		}
+		elements := value.Value.([]shared.RuntimeValue)
+
+		if p.Rest == nil && len(elements) > len(p.Elements) {
+			return false
+		}
+		for i, element := range p.Elements {
+			if element.Skipped {
+				continue
+			}
+			if i >= len(elements) {
+				if element.Default == nil {
+					return false
+				}
+				continue
+			}
+			if element.DeconstructChildren != nil && !PatternMatches(element.DeconstructChildren, &elements[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
@@ -11,31 +11,40 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalMemberExpr(node *ast.MemberExpr, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	obj, err := Evaluate(node.Object, env, dbgr)
+func evalMemberExpr(node *ast.MemberExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if ast.IsOptionalChain(node) {
+		return evalOptionalChain(node, env, dbgr, exec)
+	}
+
+	obj, err := evaluate(node.Object, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
 
-	if obj.Type != shared.Object && obj.Type != shared.Array && obj.Type != shared.ClassInstance {
+	return evalMember(node, obj, env, dbgr, exec)
+}
+
+// evalMember looks up the member of an already evaluated object
+func evalMember(node *ast.MemberExpr, obj *shared.RuntimeValue, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if obj.Type != shared.Object && obj.Type != shared.Array && obj.Type != shared.ClassInstance && obj.Type != shared.Class {
 		return nil, &errors.RuntimeError{
 			Message: fmt.Sprintf("Cannot access property of non-object or non-array (attempting to access properties of %v).", shared.Stringify(obj.Type)),
 		}
 	}
 
 	if obj.Type == shared.Object {
-		return evalMemberExpr_object(node, env, obj, dbgr)
+		return evalMemberExpr_object(node, env, obj, dbgr, exec)
 	} else if obj.Type == shared.Array {
-		return evalMemberExpr_array(node, env, dbgr)
+		return evalMemberExpr_array(node, env, obj, dbgr, exec)
 	} else {
-		return evalMemberExpr_class(node, env, dbgr)
+		return evalMemberExpr_class(node, env, obj, dbgr, exec)
 	}
 }
 
-func evalMemberExpr_object(node *ast.MemberExpr, env *environment.Environment, obj *shared.RuntimeValue, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalMemberExpr_object(node *ast.MemberExpr, env *environment.Environment, obj *shared.RuntimeValue, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	var prop *shared.RuntimeValue
 	if node.Computed {
-		val, err := Evaluate(node.Value, env, dbgr)
+		val, err := evaluate(node.Value, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
@@ -74,14 +83,14 @@ func evalMemberExpr_object(node *ast.MemberExpr, env *environment.Environment, o
 	return obj.Value.(map[string]*shared.RuntimeValue)[key], nil
 }
 
-func evalMemberExpr_array(node *ast.MemberExpr, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalMemberExpr_array(node *ast.MemberExpr, env *environment.Environment, updatedArr *shared.RuntimeValue, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	if !node.Computed {
 		return nil, &errors.RuntimeError{
 			Message: fmt.Sprintf("Cannot access property of array by non-number (attempting to access properties by %v).", node.Value.GetType()),
 		}
 	}
 
-	val, err := Evaluate(node.Value, env, dbgr)
+	val, err := evaluate(node.Value, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
@@ -93,13 +102,6 @@ func evalMemberExpr_array(node *ast.MemberExpr, env *environment.Environment, db
 	}
 	index := int(val.Value.(float64))
 
-	var updatedArr *shared.RuntimeValue
-
-	updatedArr, err = Evaluate(node.Object, env, dbgr)
-	if err != nil {
-		return nil, err
-	}
-
 	if updatedArr.Type != shared.Array {
 		return nil, &errors.RuntimeError{
 			Message: fmt.Sprintf("Cannot access property of non-array (attempting to access properties of %v).", shared.Stringify(updatedArr.Type)),
@@ -115,23 +117,28 @@ func evalMemberExpr_array(node *ast.MemberExpr, env *environment.Environment, db
 	return result, nil
 }
 
-func evalMemberExpr_class(node *ast.MemberExpr, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	obj, err := Evaluate(node.Object, env, dbgr)
-	if err != nil {
-		return nil, err
-	}
-
-	if obj.Type != shared.ClassInstance {
+func evalMemberExpr_class(node *ast.MemberExpr, env *environment.Environment, obj *shared.RuntimeValue, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	var publics map[string]bool
+	var data *environment.Environment
+	var accessors map[string]values.Accessor
+
+	switch obj.Type {
+	case shared.ClassInstance:
+		instance := obj.Value.(values.ClassInstanceValue)
+		publics, data, accessors = instance.Publics, instance.Data, instance.Accessors
+	case shared.Class:
+		class := obj.Value.(values.ClassValue)
+		publics, data = class.StaticPublics, class.Statics
+	default:
 		return nil, &errors.RuntimeError{
 			Message: fmt.Sprintf("Cannot access property of non-class instance (attempting to access properties of %v).", shared.Stringify(obj.Type)),
 		}
 	}
 
-	instance := obj.Value.(values.ClassInstanceValue)
 	var key string
 
 	if node.Computed {
-		val, err := Evaluate(node.Value, env, dbgr)
+		val, err := evaluate(node.Value, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
@@ -145,14 +152,18 @@ func evalMemberExpr_class(node *ast.MemberExpr, env *environment.Environment, db
 		key = node.Value.(*ast.Identifier).Symbol
 	}
 
+	if accessor, ok := accessors[key]; ok {
+		return callGetter(accessor, node, env, dbgr, exec)
+	}
+
 	// Access control
-	if !instance.Publics[key] {
+	if !publics[key] {
 		nilValue := values.MK_NIL()
 		return &nilValue, nil
 	}
 
 	// Lookup
-	value, err := instance.Data.LookupVar(key)
+	value, err := data.LookupVar(key)
 	if err != nil {
 		return nil, err
 	}
@@ -162,3 +173,13 @@ func evalMemberExpr_class(node *ast.MemberExpr, env *environment.Environment, db
 	}
 	return value, nil
 }
+
+// callGetter reads a property through its getter, a property with only a
+// setter reading as nil
+func callGetter(accessor values.Accessor, node *ast.MemberExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if accessor.Get == nil {
+		nilValue := values.MK_NIL()
+		return &nilValue, nil
+	}
+	return evalCall(accessor.Get, nil, &ast.CallExpr{Callee: node, SourceMetadata: node.SourceMetadata}, env, dbgr, exec)
+}
@@ -8,13 +8,25 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/shared"
 )
 
-func evalObjectExpr(o *ast.ObjectLiteral, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalObjectExpr(o *ast.ObjectLiteral, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	obj := &shared.RuntimeValue{
 		Type:  shared.Object,
 		Value: map[string]*shared.RuntimeValue{},
 	}
 
 	for _, property := range o.Properties {
+		if spread, ok := property.Value.(*ast.SpreadElement); ok {
+			val, err := evaluate(spread.Argument, env, dbgr, exec)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := SpreadProperties(obj.Value.(map[string]*shared.RuntimeValue), val); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		var runtimeVal shared.RuntimeValue
 
 		if property.Value == nil {
@@ -25,7 +37,7 @@ func evalObjectExpr(o *ast.ObjectLiteral, env *environment.Environment, dbgr *de
 
 			runtimeVal = *val
 		} else {
-			val, err := Evaluate(property.Value, env, dbgr)
+			val, err := evaluate(property.Value, env, dbgr, exec)
 			if err != nil {
 				return nil, err
 			}
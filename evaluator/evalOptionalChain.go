@@ -0,0 +1,77 @@
+package evaluator
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// evalOptionalChain evaluates a member or call expression containing `?.`,
+// which is nil as soon as an optional link meets nil. Unlike plain calls, the
+// callees of the chain are evaluated before their arguments so that
+// short-circuiting skips the arguments too
+func evalOptionalChain(node ast.Expr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	value, shortCircuited, err := evalChainLink(node, env, dbgr, exec)
+	if err != nil {
+		return nil, err
+	}
+	if shortCircuited {
+		nilValue := values.MK_NIL()
+		return &nilValue, nil
+	}
+	return value, nil
+}
+
+// evalChainObject evaluates the object or callee of a link. Parentheses end
+// the chain, a nil they wrap is met by the link like any other value
+func evalChainObject(node ast.Expr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, bool, *errors.RuntimeError) {
+	if ast.IsParenthesized(node) {
+		value, err := evaluate(node, env, dbgr, exec)
+		return value, false, err
+	}
+	return evalChainLink(node, env, dbgr, exec)
+}
+
+func evalChainLink(node ast.Expr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, bool, *errors.RuntimeError) {
+	switch n := node.(type) {
+	case *ast.MemberExpr:
+		obj, shortCircuited, err := evalChainObject(n.Object, env, dbgr, exec)
+		if err != nil || shortCircuited {
+			return nil, shortCircuited, err
+		}
+		if n.Optional && obj.Type == shared.Nil {
+			return nil, true, nil
+		}
+
+		value, err := evalMember(n, obj, env, dbgr, exec)
+		return value, false, err
+
+	case *ast.CallExpr:
+		fn, shortCircuited, err := evalChainObject(n.Callee, env, dbgr, exec)
+		if err != nil || shortCircuited {
+			return nil, shortCircuited, err
+		}
+		if n.Optional && fn.Type == shared.Nil {
+			return nil, true, nil
+		}
+
+		evaluatedArgs, err := evalElements(n.Args, env, dbgr, exec)
+		if err != nil {
+			return nil, false, err
+		}
+		args := make([]*shared.RuntimeValue, len(evaluatedArgs))
+		for i := range evaluatedArgs {
+			args[i] = &evaluatedArgs[i]
+		}
+
+		value, err := evalCall(fn, args, n, env, dbgr, exec)
+		return value, false, err
+
+	default:
+		value, err := evaluate(node, env, dbgr, exec)
+		return value, false, err
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalProgram(astNode *ast.Program, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalProgram(astNode *ast.Program, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	// Push the <main> frame
 	if dbgr != nil && dbgr.IsDebuggable(ast.ProgramNode) {
 		dbgr.PushFrame(debugger.StackFrame{
@@ -23,7 +23,7 @@ func evalProgram(astNode *ast.Program, env *environment.Environment, dbgr *debug
 	result := values.MK_NIL()
 
 	for _, stmt := range astNode.Stmts {
-		evaluated, err := Evaluate(stmt, env, dbgr)
+		evaluated, err := evaluate(stmt, env, dbgr, exec)
 		if err != nil {
 			if err.InternalCommunicationProtocol != nil && err.InternalCommunicationProtocol.Type == errors.ICP_Return {
 				return err.InternalCommunicationProtocol.RValue, nil
@@ -32,7 +32,7 @@ func evalProgram(astNode *ast.Program, env *environment.Environment, dbgr *debug
 			if dbgr != nil {
 				dbgr.TakeSnapshot()
 			}
-			return nil, err
+			return nil, strayControlFlow(err)
 		}
 
 		if evaluated != nil {
@@ -9,8 +9,8 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalReturnStmt(node *ast.ReturnStmt, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	evaluated, err := Evaluate(node.Value, env, dbgr)
+func evalReturnStmt(node *ast.ReturnStmt, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	evaluated, err := evaluate(node.Value, env, dbgr, exec)
 	if err != nil {
 		return nil, err
 	}
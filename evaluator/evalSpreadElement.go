@@ -0,0 +1,85 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// evalElements evaluates the arguments of a call or the elements of an array
+// literal, expanding every spread element into the values it iterates over
+func evalElements(exprs []ast.Expr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) ([]shared.RuntimeValue, *errors.RuntimeError) {
+	results := make([]shared.RuntimeValue, 0, len(exprs))
+
+	for _, expr := range exprs {
+		if spread, ok := expr.(*ast.SpreadElement); ok {
+			value, err := evaluate(spread.Argument, env, dbgr, exec)
+			if err != nil {
+				return nil, err
+			}
+
+			items, err := IterationValues(value)
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, items...)
+			continue
+		}
+
+		result, err := evaluate(expr, env, dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// SpreadProperties copies the properties of an object, or the public members
+// of a class instance, into target. Spreading nil copies nothing
+func SpreadProperties(target map[string]*shared.RuntimeValue, value *shared.RuntimeValue) *errors.RuntimeError {
+	switch value.Type {
+	case shared.Nil:
+		return nil
+
+	case shared.Object:
+		for key, property := range value.Value.(map[string]*shared.RuntimeValue) {
+			copied := *property
+			target[key] = &copied
+		}
+		return nil
+
+	case shared.ClassInstance:
+		instance := value.Value.(values.ClassInstanceValue)
+		for name, isPublic := range instance.Publics {
+			if !isPublic {
+				continue
+			}
+
+			member, err := instance.Data.LookupVar(name)
+			if err != nil {
+				return err
+			}
+
+			copied := values.MK_NIL()
+			if member != nil {
+				copied = *member
+			}
+			target[name] = &copied
+		}
+		return nil
+
+	default:
+		return &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot spread a %s into an object (only objects and class instances can be spread).", shared.Stringify(value.Type)),
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package evaluator
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// evalSwitchStmt runs the body of the first case listing a value equal to the
+// discriminant, or of the default case, in a scope of its own. Values are
+// evaluated in order until one matches
+func evalSwitchStmt(node *ast.SwitchStmt, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	discriminant, err := evaluate(node.Discriminant, env, dbgr, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched, defaultCase *ast.SwitchCase
+	for i := range node.Cases {
+		switchCase := &node.Cases[i]
+		if len(switchCase.Values) == 0 {
+			defaultCase = switchCase
+			continue
+		}
+
+		found, err := listsValue(switchCase.Values, discriminant, env, dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			matched = switchCase
+			break
+		}
+	}
+	if matched == nil {
+		matched = defaultCase
+	}
+
+	if matched != nil {
+		scope := environment.NewEnvironment(env)
+		for _, stmt := range matched.Body {
+			if _, err := evaluate(stmt, scope, dbgr, exec); err != nil {
+				if isControlFlowFor(err, errors.ICP_Break, "") {
+					break
+				}
+				return nil, err
+			}
+		}
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
+
+// listsValue evaluates the values of a case in order, until one of them is
+// equal to value
+func listsValue(exprs []ast.Expr, value *shared.RuntimeValue, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (bool, *errors.RuntimeError) {
+	for _, expr := range exprs {
+		candidate, err := evaluate(expr, env, dbgr, exec)
+		if err != nil {
+			return false, err
+		}
+		equal, err := compareEqual(value, candidate, false)
+		if err != nil {
+			return false, err
+		}
+		if equal.Value.(bool) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,31 @@
+package evaluator
+
+import (
+	"strings"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/helpers"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// evalTemplateLiteral interpolates values converted with helpers.ToString
+func evalTemplateLiteral(node *ast.TemplateLiteral, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	var sb strings.Builder
+	sb.WriteString(node.Quasis[0])
+
+	for i, expr := range node.Exprs {
+		value, err := evaluate(expr, env, dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+		sb.WriteString(helpers.ToString(value))
+		sb.WriteString(node.Quasis[i+1])
+	}
+
+	result := values.MK_STRING(sb.String())
+	return &result, nil
+}
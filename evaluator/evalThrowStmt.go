@@ -0,0 +1,51 @@
+package evaluator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// ThrownMessage derives a human readable message for a thrown value, used
+// when the error escapes the script and reaches the host
+func ThrownMessage(value *shared.RuntimeValue) string {
+	switch value.Type {
+	case shared.String:
+		return value.Value.(string)
+	case shared.Number:
+		return strconv.FormatFloat(value.Value.(float64), 'f', -1, 64)
+	case shared.Boolean:
+		return strconv.FormatBool(value.Value.(bool))
+	case shared.Object:
+		if message, ok := value.Value.(map[string]*shared.RuntimeValue)["message"]; ok && message != nil && message.Type == shared.String {
+			return message.Value.(string)
+		}
+	case shared.ClassInstance:
+		instance := value.Value.(values.ClassInstanceValue)
+		if instance.Publics["message"] {
+			if message, err := instance.Data.LookupVar("message"); err == nil && message.Type == shared.String {
+				return message.Value.(string)
+			}
+		}
+	}
+
+	return fmt.Sprintf("Uncaught %s", shared.Stringify(value.Type))
+}
+
+func evalThrowStmt(node *ast.ThrowStmt, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	value, err := evaluate(node.Value, env, dbgr, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, &errors.RuntimeError{
+		Message: ThrownMessage(value),
+		Thrown:  value,
+	}
+}
@@ -9,7 +9,24 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalTryCatch(node *ast.TryCatchStmt, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalTryCatch(node *ast.TryCatchStmt, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	result, err := evalTryCatch_try(node, env, dbgr, exec)
+
+	// The finally block runs however the try/catch was left. An error or
+	// control flow event raised inside of it replaces the pending one
+	if node.Finally != nil {
+		scope := environment.NewEnvironment(env)
+		for _, stmt := range node.Finally {
+			if _, finallyErr := evaluate(stmt, scope, dbgr, exec); finallyErr != nil {
+				return nil, finallyErr
+			}
+		}
+	}
+
+	return result, err
+}
+
+func evalTryCatch_try(node *ast.TryCatchStmt, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	scope := environment.NewEnvironment(env)
 
 	// I realized that just removing the snapshot at catching the error
@@ -24,8 +41,15 @@ func evalTryCatch(node *ast.TryCatchStmt, env *environment.Environment, dbgr *de
 
 	for _, stmt := range node.Try {
 
-		_, err := Evaluate(stmt, scope, dbgr)
+		_, err := evaluate(stmt, scope, dbgr, exec)
 		if err != nil {
+			// return, break and continue are not errors, let them through
+			// to the function or loop they are meant for. Without a catch
+			// clause, errors propagate too, and so do execution limits
+			if err.InternalCommunicationProtocol != nil || node.CatchVar == "" || !err.Catchable() {
+				return nil, err
+			}
+
 			// save the last snapshot
 			var lastSnapshot debugger.Snapshot
 			if dbgr != nil && len(dbgr.Snapshots) > 0 {
@@ -41,10 +65,27 @@ func evalTryCatch(node *ast.TryCatchStmt, env *environment.Environment, dbgr *de
 			}
 			scope = environment.NewEnvironment(env)
 
-			// declare the catch variable
+			// declare the catch variable, values raised with `throw`
+			// reach the catch block untouched
+			if err.Thrown != nil {
+				_, err = scope.DeclareVar(node.CatchVar, *err.Thrown, false)
+				if err != nil {
+					return nil, err
+				}
+				return evalTryCatch_catch(node, scope, dbgr, exec)
+			}
 			catchVar_message := values.MK_STRING(err.Message)
 			catchVar_stack_raw := []shared.RuntimeValue{}
-			for _, frame := range lastSnapshot.Stack {
+			// A stack overflow reports the frames it involved, whether
+			// or not a debugger is attached
+			frames := lastSnapshot.Stack
+			if len(err.Frames) > 0 {
+				frames = make(debugger.CallStack, len(err.Frames))
+				for i, frame := range err.Frames {
+					frames[i] = debugger.StackFrame(frame)
+				}
+			}
+			for _, frame := range frames {
 				name := values.MK_STRING(frame.Name)
 				line := values.MK_NUMBER(float64(frame.Line))
 				file := values.MK_STRING(frame.Filename)
@@ -64,22 +105,26 @@ func evalTryCatch(node *ast.TryCatchStmt, env *environment.Environment, dbgr *de
 				return nil, err
 			}
 
-			var lastResult *shared.RuntimeValue = nil
-			for _, stmt := range node.Catch {
-				res, catchErr := Evaluate(stmt, scope, dbgr)
-				if catchErr != nil {
-					return nil, catchErr
-				}
-				lastResult = res
-			}
-			if lastResult != nil {
-				return lastResult, nil
-			}
-			result := values.MK_NIL()
-			return &result, nil
+			return evalTryCatch_catch(node, scope, dbgr, exec)
 		}
 	}
 
 	result := values.MK_NIL()
 	return &result, nil
 }
+
+func evalTryCatch_catch(node *ast.TryCatchStmt, scope *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	var lastResult *shared.RuntimeValue = nil
+	for _, stmt := range node.Catch {
+		res, catchErr := evaluate(stmt, scope, dbgr, exec)
+		if catchErr != nil {
+			return nil, catchErr
+		}
+		lastResult = res
+	}
+	if lastResult != nil {
+		return lastResult, nil
+	}
+	result := values.MK_NIL()
+	return &result, nil
+}
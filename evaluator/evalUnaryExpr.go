@@ -0,0 +1,52 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+func evalUnaryExpr(node *ast.UnaryExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	operand, err := evaluate(node.Operand, env, dbgr, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	return UnaryOp(node.Operator, operand)
+}
+
+// UnaryOp applies an arithmetic, bitwise or typeof unary operator to an
+// evaluated operand. The vm package shares it, like BinaryOp.
+func UnaryOp(opr ast.UnaryOperator, operand *shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if opr == ast.TypeOf {
+		result := values.MK_STRING(shared.Stringify(operand.Type))
+		return &result, nil
+	}
+
+	if operand.Type != shared.Number {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Unary `%s` can only be applied to numbers. Attempted to apply it to `%s`", opr, shared.Stringify(operand.Type)),
+		}
+	}
+
+	value := operand.Value.(float64)
+	var result shared.RuntimeValue
+	switch opr {
+	case ast.UnaryMinus:
+		result = values.MK_NUMBER(-value)
+	case ast.UnaryPlus:
+		result = values.MK_NUMBER(value)
+	case ast.BitwiseNOT:
+		result = values.MK_NUMBER(float64(^toInt32(value)))
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Unknown unary operator: %v.", opr),
+		}
+	}
+	return &result, nil
+}
@@ -0,0 +1,41 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+func evalUpdateExpr(node *ast.UpdateExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	return assign(node.Operand, func(current func() (*shared.RuntimeValue, *errors.RuntimeError)) (*shared.RuntimeValue, *shared.RuntimeValue, *errors.RuntimeError) {
+		cur, err := current()
+		if err != nil {
+			return nil, nil, err
+		}
+		value, err := UpdateOp(node.Operator, cur)
+		if err != nil || node.Prefix {
+			return value, nil, err
+		}
+		return value, cur, nil
+	}, env, dbgr, exec)
+}
+
+// UpdateOp returns the value `++` or `--` stores, shared with the VM
+func UpdateOp(opr ast.UpdateOperator, operand *shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if operand.Type != shared.Number {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("`%s` can only be applied to numbers. Attempted to apply it to `%s`", opr, shared.Stringify(operand.Type)),
+		}
+	}
+
+	one := values.MK_NUMBER(1)
+	if opr == ast.Increment {
+		return BinaryOp(ast.Plus, operand, &one)
+	}
+	return BinaryOp(ast.Minus, operand, &one)
+}
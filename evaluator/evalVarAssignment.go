@@ -7,27 +7,58 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/debugger"
 	"github.com/dev-kas/virtlang-go/v4/environment"
 	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/helpers"
 	"github.com/dev-kas/virtlang-go/v4/shared"
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalVarAssignment(node *ast.VarAssignmentExpr, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
-	if node.Assignee.GetType() == ast.IdentifierNode {
-		varname := node.Assignee.(*ast.Identifier).Symbol
+func evalVarAssignment(node *ast.VarAssignmentExpr, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	return assign(node.Assignee, func(current func() (*shared.RuntimeValue, *errors.RuntimeError)) (*shared.RuntimeValue, *shared.RuntimeValue, *errors.RuntimeError) {
+		return compoundAssignment(node, current, env, dbgr, exec)
+	}, env, dbgr, exec)
+}
+
+// assignment computes the value to store into an assignment target from the
+// target's current value, which is only read when asked for. A nil stored
+// value skips the store, a nil result makes the expression evaluate to the
+// stored value
+type assignment func(current func() (*shared.RuntimeValue, *errors.RuntimeError)) (stored, result *shared.RuntimeValue, err *errors.RuntimeError)
 
-		value, err := Evaluate(node.Value, env, dbgr)
+// assign stores into an identifier or a member expression, shared by plain
+// and compound assignments and by `++`/`--`
+func assign(assignee ast.Expr, compute assignment, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if assignee.GetType() == ast.IdentifierNode {
+		varname := assignee.(*ast.Identifier).Symbol
+
+		value, result, err := compute(func() (*shared.RuntimeValue, *errors.RuntimeError) {
+			return env.LookupVar(varname)
+		})
 		if err != nil {
 			return nil, err
 		}
+		if value == nil {
+			return result, nil
+		}
 
-		return env.AssignVar(varname, *value)
-	} else if node.Assignee.GetType() == ast.MemberExprNode {
-		memberExpr := node.Assignee.(*ast.MemberExpr)
-		obj, err := Evaluate(memberExpr.Object, env, dbgr)
+		assigned, err := env.AssignVar(varname, *value)
+		if err != nil || result == nil {
+			return assigned, err
+		}
+		return result, nil
+	} else if assignee.GetType() == ast.MemberExprNode {
+		memberExpr := assignee.(*ast.MemberExpr)
+		obj, err := evaluate(memberExpr.Object, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
 
+		if obj.Type == shared.ClassInstance {
+			return assignClassMember(memberExpr, obj.Value.(values.ClassInstanceValue), compute, env, dbgr, exec)
+		}
+		if obj.Type == shared.Class {
+			return assignClassMember(memberExpr, StaticMembers(obj.Value.(values.ClassValue)), compute, env, dbgr, exec)
+		}
+
 		if obj.Type != shared.Object && obj.Type != shared.Array {
 			return nil, &errors.RuntimeError{
 				Message: fmt.Sprintf("Cannot access property of non-object (attempting to access properties of %v).", shared.Stringify(obj.Type)),
@@ -35,7 +66,7 @@ func evalVarAssignment(node *ast.VarAssignmentExpr, env *environment.Environment
 		}
 
 		if obj.Type == shared.Array {
-			indexVal, err := Evaluate(memberExpr.Value, env, dbgr)
+			indexVal, err := evaluate(memberExpr.Value, env, dbgr, exec)
 			if err != nil {
 				return nil, err
 			}
@@ -66,10 +97,19 @@ func evalVarAssignment(node *ast.VarAssignmentExpr, env *environment.Environment
 				obj.Value = array
 			}
 
-			value, err := Evaluate(node.Value, env, dbgr)
+			value, result, err := compute(func() (*shared.RuntimeValue, *errors.RuntimeError) {
+				current := array[index]
+				return &current, nil
+			})
 			if err != nil {
 				return nil, err
 			}
+			if value == nil {
+				return result, nil
+			}
+			if result == nil {
+				result = value
+			}
 
 			array[index] = *value
 			obj.Value = array
@@ -82,8 +122,8 @@ func evalVarAssignment(node *ast.VarAssignmentExpr, env *environment.Environment
 					return nil, err
 				}
 			case ast.MemberExprNode:
-				memberExpr := node.Assignee.(*ast.MemberExpr)
-				obj, err := Evaluate(memberExpr.Object, env, dbgr)
+				memberExpr := assignee.(*ast.MemberExpr)
+				obj, err := evaluate(memberExpr.Object, env, dbgr, exec)
 				if err != nil {
 					return nil, err
 				}
@@ -92,7 +132,7 @@ func evalVarAssignment(node *ast.VarAssignmentExpr, env *environment.Environment
 				case shared.Object:
 					var prop *shared.RuntimeValue
 					if memberExpr.Computed {
-						val, err := Evaluate(memberExpr.Value, env, dbgr)
+						val, err := evaluate(memberExpr.Value, env, dbgr, exec)
 						if err != nil {
 							return nil, err
 						}
@@ -117,16 +157,16 @@ func evalVarAssignment(node *ast.VarAssignmentExpr, env *environment.Environment
 					}
 
 					obj.Value.(map[string]*shared.RuntimeValue)[key] = value
-					return value, nil
+					return result, nil
 				}
 			}
 
-			return value, nil
+			return result, nil
 		}
 
 		var prop *shared.RuntimeValue
 		if memberExpr.Computed {
-			val, err := Evaluate(memberExpr.Value, env, dbgr)
+			val, err := evaluate(memberExpr.Value, env, dbgr, exec)
 			if err != nil {
 				return nil, err
 			}
@@ -154,17 +194,159 @@ func evalVarAssignment(node *ast.VarAssignmentExpr, env *environment.Environment
 			key = prop.Value.(string)
 		}
 
-		value, err := Evaluate(node.Value, env, dbgr)
+		props := obj.Value.(map[string]*shared.RuntimeValue)
+		value, result, err := compute(func() (*shared.RuntimeValue, *errors.RuntimeError) {
+			if current, ok := props[key]; ok {
+				return current, nil
+			}
+			nilValue := values.MK_NIL()
+			return &nilValue, nil
+		})
 		if err != nil {
 			return nil, err
 		}
+		if value == nil {
+			return result, nil
+		}
+		if result == nil {
+			result = value
+		}
 
-		obj.Value.(map[string]*shared.RuntimeValue)[key] = value
+		props[key] = value
 
-		return value, nil
+		return result, nil
 	} else {
 		return nil, &errors.RuntimeError{
 			Message: "Cannot access property of object by non-string key.",
 		}
 	}
 }
+
+// assignClassMember stores into a member of a class instance, through its
+// setter when it has one
+func assignClassMember(node *ast.MemberExpr, instance values.ClassInstanceValue, compute assignment, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	var key string
+	if node.Computed {
+		val, err := evaluate(node.Value, env, dbgr, exec)
+		if err != nil {
+			return nil, err
+		}
+		if val.Type != shared.String {
+			return nil, &errors.RuntimeError{
+				Message: fmt.Sprintf("Cannot access property of class instance by non-string (attempting to access properties by %v).", shared.Stringify(val.Type)),
+			}
+		}
+		key = val.Value.(string)
+	} else {
+		key = node.Value.(*ast.Identifier).Symbol
+	}
+
+	accessor, hasAccessor := instance.Accessors[key]
+	value, result, err := compute(func() (*shared.RuntimeValue, *errors.RuntimeError) {
+		if hasAccessor {
+			return callGetter(accessor, node, env, dbgr, exec)
+		}
+		if err := CheckMemberAssignable(instance, key); err != nil {
+			return nil, err
+		}
+		return instance.Data.LookupVar(key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return result, nil
+	}
+	if result == nil {
+		result = value
+	}
+
+	if err := CheckMemberAssignable(instance, key); err != nil {
+		return nil, err
+	}
+	if hasAccessor {
+		site := &ast.CallExpr{Callee: node, SourceMetadata: node.SourceMetadata}
+		if _, err := evalCall(accessor.Set, []*shared.RuntimeValue{value}, site, env, dbgr, exec); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	if _, err := instance.Data.AssignVar(key, *value); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CheckMemberAssignable reports an error unless code outside of a class may
+// assign to the member of one of its instances: a public member, or a
+// property whose accessor has a setter
+func CheckMemberAssignable(instance values.ClassInstanceValue, name string) *errors.RuntimeError {
+	if accessor, ok := instance.Accessors[name]; ok {
+		if accessor.Set == nil {
+			return &errors.RuntimeError{
+				Message: fmt.Sprintf("Cannot assign to `%s` of class `%s`, it only has a getter.", name, instance.Class.Name),
+			}
+		}
+		return nil
+	}
+
+	isPublic, declared := instance.Publics[name]
+	if !declared {
+		return &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot assign to `%s`, class `%s` has no such member.", name, instance.Class.Name),
+		}
+	}
+	if !isPublic {
+		return &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot assign to private member `%s` of class `%s`.", name, instance.Class.Name),
+		}
+	}
+	return nil
+}
+
+// StaticMembers views the static members of a class as an instance, so that
+// assigning to them follows the rules of instance members
+func StaticMembers(class values.ClassValue) values.ClassInstanceValue {
+	return values.ClassInstanceValue{Class: class, Publics: class.StaticPublics, Data: class.Statics}
+}
+
+// compoundAssignment computes the value stored by an assignment. The logical
+// forms only evaluate and store their right-hand side when the current value
+// doesn't short-circuit, like the matching logical expressions
+func compoundAssignment(node *ast.VarAssignmentExpr, current func() (*shared.RuntimeValue, *errors.RuntimeError), env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *shared.RuntimeValue, *errors.RuntimeError) {
+	if node.Operator == "" {
+		value, err := evaluate(node.Value, env, dbgr, exec)
+		return value, nil, err
+	}
+
+	cur, err := current()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch node.Operator {
+	case ast.NilCoalescingAssign, ast.LogicalORAssign, ast.LogicalANDAssign:
+		var skip bool
+		switch node.Operator {
+		case ast.NilCoalescingAssign:
+			skip = !(cur.Type == shared.Nil && cur.Value == nil)
+		case ast.LogicalORAssign:
+			skip = helpers.IsTruthy(cur)
+		case ast.LogicalANDAssign:
+			skip = !helpers.IsTruthy(cur)
+		}
+		if skip {
+			return nil, cur, nil
+		}
+		value, err := evaluate(node.Value, env, dbgr, exec)
+		return value, nil, err
+	}
+
+	rhs, err := evaluate(node.Value, env, dbgr, exec)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := BinaryOp(ast.BinaryOperator(node.Operator.Operator()), cur, rhs)
+	return value, nil, err
+}
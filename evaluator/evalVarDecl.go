@@ -9,9 +9,9 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
-func evalVarDecl(node *ast.VarDeclaration, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+func evalVarDecl(node *ast.VarDeclaration, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	if node.Value != nil {
-		evaluated, err := Evaluate(node.Value, env, dbgr)
+		evaluated, err := evaluate(node.Value, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
@@ -13,9 +13,32 @@ func isControlFlow(err *errors.RuntimeError, kind errors.InternalCommunicationPr
 	return err != nil && err.InternalCommunicationProtocol != nil && err.InternalCommunicationProtocol.Type == kind
 }
 
-func evalWhileLoop(astNode *ast.WhileLoop, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+// isControlFlowFor reports whether err is a `break` or `continue` aimed at a
+// statement with the given label. Unlabeled ones aim at the innermost one
+func isControlFlowFor(err *errors.RuntimeError, kind errors.InternalCommunicationProtocolTypes, label string) bool {
+	return isControlFlow(err, kind) && (err.InternalCommunicationProtocol.Label == "" || err.InternalCommunicationProtocol.Label == label)
+}
+
+// strayControlFlow turns a `break` or `continue` that reached the end of a
+// function or program without meeting its loop into a plain error
+func strayControlFlow(err *errors.RuntimeError) *errors.RuntimeError {
+	if !isControlFlow(err, errors.ICP_Break) && !isControlFlow(err, errors.ICP_Continue) {
+		return err
+	}
+	return &errors.RuntimeError{
+		Message:  err.Message,
+		Filename: err.Filename,
+		Line:     err.Line,
+	}
+}
+
+func evalWhileLoop(astNode *ast.WhileLoop, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	for {
-		cond, err := Evaluate(astNode.Condition, env, dbgr)
+		if err := exec.Interrupted(); err != nil {
+			return nil, err
+		}
+
+		cond, err := evaluate(astNode.Condition, env, dbgr, exec)
 		if err != nil {
 			return nil, err
 		}
@@ -23,12 +46,12 @@ func evalWhileLoop(astNode *ast.WhileLoop, env *environment.Environment, dbgr *d
 		if cond.Type == shared.Boolean && cond.Value.(bool) {
 			scope := environment.NewEnvironment(env)
 			for _, stmt := range astNode.Body {
-				_, err := Evaluate(stmt, scope, dbgr)
+				_, err := evaluate(stmt, scope, dbgr, exec)
 				if err != nil {
 					switch {
-					case isControlFlow(err, errors.ICP_Continue):
+					case isControlFlowFor(err, errors.ICP_Continue, astNode.Label):
 						goto ContinueLoop
-					case isControlFlow(err, errors.ICP_Break):
+					case isControlFlowFor(err, errors.ICP_Break, astNode.Label):
 						goto BreakLoop
 					default:
 						return nil, err
@@ -13,8 +13,24 @@ import (
 
 // `dbgr` circulates the evaluator
 func Evaluate(astNode ast.Stmt, env *environment.Environment, dbgr *debugger.Debugger) (*shared.RuntimeValue, *errors.RuntimeError) {
+	return EvaluateWithOptions(astNode, env, dbgr, Options{})
+}
+
+// EvaluateWithOptions evaluates a node like Evaluate, aborting with an error
+// whose Kind tells which limit was hit. Such errors report true from IsLimit,
+// and scripts cannot catch them except for stack overflows.
+func EvaluateWithOptions(astNode ast.Stmt, env *environment.Environment, dbgr *debugger.Debugger, opts Options) (*shared.RuntimeValue, *errors.RuntimeError) {
+	return evaluate(astNode, env, dbgr, newExecution(opts))
+}
+
+// `exec` circulates along with `dbgr`, accounting for the resources used
+func evaluate(astNode ast.Stmt, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	type_ := astNode.GetType()
 
+	if err := exec.Step(); err != nil {
+		return nil, err
+	}
+
 	// If debugger is attached
 	if dbgr != nil {
 		dbgr.CurrentFile = astNode.GetSourceMetadata().Filename
@@ -30,6 +46,16 @@ func Evaluate(astNode ast.Stmt, env *environment.Environment, dbgr *debugger.Deb
 		}
 	}
 
+	result, err := evaluateNode(astNode, type_, env, dbgr, exec)
+	// The innermost node an error passes through locates it
+	if err != nil && err.Line == 0 {
+		err.Filename = astNode.GetSourceMetadata().Filename
+		err.Line = astNode.GetSourceMetadata().StartLine
+	}
+	return result, err
+}
+
+func evaluateNode(astNode ast.Stmt, type_ ast.NodeType, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
 	switch type_ {
 	case ast.NumericLiteralNode:
 		result := values.MK_NUMBER(astNode.(*ast.NumericLiteral).Value)
@@ -39,53 +65,89 @@ func Evaluate(astNode ast.Stmt, env *environment.Environment, dbgr *debugger.Deb
 		result := values.MK_STRING(astNode.(*ast.StringLiteral).Value)
 		return &result, nil
 
+	case ast.TemplateLiteralNode:
+		return evalTemplateLiteral(astNode.(*ast.TemplateLiteral), env, dbgr, exec)
+
 	case ast.IdentifierNode:
 		return evalIdentifier(astNode.(*ast.Identifier), env)
 
 	case ast.ObjectLiteralNode:
-		return evalObjectExpr(astNode.(*ast.ObjectLiteral), env, dbgr)
+		return evalObjectExpr(astNode.(*ast.ObjectLiteral), env, dbgr, exec)
 
 	case ast.ArrayLiteralNode:
-		return evalArrayExpr(astNode.(*ast.ArrayLiteral), env, dbgr)
+		return evalArrayExpr(astNode.(*ast.ArrayLiteral), env, dbgr, exec)
 
 	case ast.CallExprNode:
-		return evalCallExpr(astNode.(*ast.CallExpr), env, dbgr)
+		return evalCallExpr(astNode.(*ast.CallExpr), env, dbgr, exec)
 
 	case ast.MemberExprNode:
-		return evalMemberExpr(astNode.(*ast.MemberExpr), env, dbgr)
+		return evalMemberExpr(astNode.(*ast.MemberExpr), env, dbgr, exec)
 
 	case ast.VarAssignmentExprNode:
-		return evalVarAssignment(astNode.(*ast.VarAssignmentExpr), env, dbgr)
+		return evalVarAssignment(astNode.(*ast.VarAssignmentExpr), env, dbgr, exec)
 
 	case ast.BinaryExprNode:
-		return evalBinEx(astNode.(*ast.BinaryExpr), env, dbgr)
+		return evalBinEx(astNode.(*ast.BinaryExpr), env, dbgr, exec)
+
+	case ast.UnaryExprNode:
+		return evalUnaryExpr(astNode.(*ast.UnaryExpr), env, dbgr, exec)
+
+	case ast.UpdateExprNode:
+		return evalUpdateExpr(astNode.(*ast.UpdateExpr), env, dbgr, exec)
+
+	case ast.ConditionalExprNode:
+		return evalConditionalExpr(astNode.(*ast.ConditionalExpr), env, dbgr, exec)
+
+	case ast.MatchExprNode:
+		return evalMatchExpr(astNode.(*ast.MatchExpr), env, dbgr, exec)
 
 	case ast.CompareExprNode:
-		return evalComEx(astNode.(*ast.CompareExpr), env, dbgr)
+		return evalComEx(astNode.(*ast.CompareExpr), env, dbgr, exec)
 
 	case ast.LogicalExprNode:
-		return evalLogicEx(astNode.(*ast.LogicalExpr), env, dbgr)
+		return evalLogicEx(astNode.(*ast.LogicalExpr), env, dbgr, exec)
 
 	case ast.ProgramNode:
-		return evalProgram(astNode.(*ast.Program), env, dbgr)
+		return evalProgram(astNode.(*ast.Program), env, dbgr, exec)
 
 	case ast.VarDeclarationNode:
-		return evalVarDecl(astNode.(*ast.VarDeclaration), env, dbgr)
+		return evalVarDecl(astNode.(*ast.VarDeclaration), env, dbgr, exec)
 
 	case ast.FnDeclarationNode:
 		return evalFnDecl(astNode.(*ast.FnDeclaration), env)
 
 	case ast.IfStatementNode:
-		return evalIfStmt(astNode.(*ast.IfStatement), env, dbgr)
+		return evalIfStmt(astNode.(*ast.IfStatement), env, dbgr, exec)
 
 	case ast.WhileLoopNode:
-		return evalWhileLoop(astNode.(*ast.WhileLoop), env, dbgr)
+		return evalWhileLoop(astNode.(*ast.WhileLoop), env, dbgr, exec)
+
+	case ast.DoWhileLoopNode:
+		return evalDoWhileLoop(astNode.(*ast.DoWhileLoop), env, dbgr, exec)
+
+	case ast.ForLoopNode:
+		return evalForLoop(astNode.(*ast.ForLoop), env, dbgr, exec)
+
+	case ast.ForInLoopNode:
+		return evalForInLoop(astNode.(*ast.ForInLoop), env, dbgr, exec)
+
+	case ast.ForOfLoopNode:
+		return evalForOfLoop(astNode.(*ast.ForOfLoop), env, dbgr, exec)
+
+	case ast.BlockStmtNode:
+		return evalBlockStmt(astNode.(*ast.BlockStmt), env, dbgr, exec)
+
+	case ast.SwitchStmtNode:
+		return evalSwitchStmt(astNode.(*ast.SwitchStmt), env, dbgr, exec)
 
 	case ast.TryCatchStmtNode:
-		return evalTryCatch(astNode.(*ast.TryCatchStmt), env, dbgr)
+		return evalTryCatch(astNode.(*ast.TryCatchStmt), env, dbgr, exec)
 
 	case ast.ReturnStmtNode:
-		return evalReturnStmt(astNode.(*ast.ReturnStmt), env, dbgr)
+		return evalReturnStmt(astNode.(*ast.ReturnStmt), env, dbgr, exec)
+
+	case ast.ThrowStmtNode:
+		return evalThrowStmt(astNode.(*ast.ThrowStmt), env, dbgr, exec)
 
 	case ast.BreakStmtNode:
 		return evalBreakStmt(astNode.(*ast.BreakStmt), env)
@@ -94,16 +156,16 @@ func Evaluate(astNode ast.Stmt, env *environment.Environment, dbgr *debugger.Deb
 		return evalContinueStmt(astNode.(*ast.ContinueStmt), env)
 
 	case ast.ClassNode:
-		return evalClass(astNode.(*ast.Class), env)
+		return evalClass(astNode.(*ast.Class), env, dbgr, exec)
 
 	case ast.ClassMethodNode:
 		return evalClassMethod(astNode.(*ast.ClassMethod), env)
 
 	case ast.ClassPropertyNode:
-		return evalClassProperty(astNode.(*ast.ClassProperty), env, dbgr)
+		return evalClassProperty(astNode.(*ast.ClassProperty), env, dbgr, exec)
 
 	case ast.DestructureDeclarationNode:
-		return evalDestructureDeclaration(astNode.(*ast.DestructureDeclaration), env, dbgr)
+		return evalDestructureDeclaration(astNode.(*ast.DestructureDeclaration), env, dbgr, exec)
 
 	default:
 		return nil, &errors.RuntimeError{
@@ -1,12 +1,16 @@
 package evaluator_test
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
 	"github.com/dev-kas/virtlang-go/v4/evaluator"
 	"github.com/dev-kas/virtlang-go/v4/internal/testhelpers"
 	"github.com/dev-kas/virtlang-go/v4/parser"
@@ -54,6 +58,41 @@ func TestNumbers(t *testing.T) {
 				Value: float64(3.14159),
 			},
 		},
+		{
+			input: "0xFF",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(255),
+			},
+		},
+		{
+			input: "0b1010",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(10),
+			},
+		},
+		{
+			input: "0o755",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(493),
+			},
+		},
+		{
+			input: "2.5e-3",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(0.0025),
+			},
+		},
+		{
+			input: "1_000_000",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(1000000),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -63,7 +102,7 @@ func TestNumbers(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("expected no error, got %v", synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("expected no error, got %v", runErr)
 		}
@@ -139,7 +178,7 @@ func TestStrings(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("expected no error, got %v", synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("expected no error, got %v", runErr)
 		}
@@ -262,7 +301,7 @@ func TestObjects(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("expected no error, got %v", synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("expected no error, got %v", runErr)
 		}
@@ -394,7 +433,7 @@ func TestBinaryExpression(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("[%d] expected no error, got %v", i+1, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("[%d] expected no error, got %v", i+1, runErr)
 		}
@@ -602,7 +641,7 @@ func TestComparisonOperators(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("[%d] expected no error, got %v, input: %v", i+1, synErr, test.input)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("[%d] expected no error, got %v, input: %v", i+1, runErr, test.input)
 		}
@@ -746,7 +785,7 @@ func TestVariableDeclarationAndAssignment(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -901,7 +940,7 @@ func TestFunctions(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -1113,7 +1152,7 @@ func TestIfStatements(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -1244,7 +1283,7 @@ func TestWhileLoops(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -1357,7 +1396,7 @@ func TestTryCatch(t *testing.T) {
 				t.Fatalf("parser error: %v", synErr)
 			}
 
-			evaluated, runErr := evaluator.Evaluate(program, env, nil)
+			evaluated, runErr := testhelpers.Evaluate(t, program, env)
 			if runErr != nil {
 				t.Fatalf("evaluation error: %v", runErr)
 			}
@@ -1468,7 +1507,7 @@ func TestReturnStatements(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -1626,7 +1665,7 @@ func TestContinueKeyword(t *testing.T) {
 			},
 		},
 		{
-			input: "let err = ''\ntry {continue} catch e {err = e}\nerr.message",
+			input: "fn skip() { continue }\nlet err = ''\ntry { skip() } catch e {err = e}\nerr.message",
 			output: shared.RuntimeValue{
 				Type:  shared.String,
 				Value: "`continue` statement used outside of a loop context.",
@@ -1641,7 +1680,7 @@ func TestContinueKeyword(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -1800,12 +1839,19 @@ func TestBreakKeyword(t *testing.T) {
 			},
 		},
 		{
-			input: "let err = ''\ntry {break} catch e {err = e}\nerr.message",
+			input: "fn stop() { break }\nlet err = ''\ntry { stop() } catch e {err = e}\nerr.message",
 			output: shared.RuntimeValue{
 				Type:  shared.String,
 				Value: "`break` statement used outside of a loop context.",
 			},
 		},
+		{
+			input: "let n = 0\nfn stop() { break }\nwhile (n < 10) { n = n + 1\ntry { stop() } catch e {} }\nn",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(10),
+			},
+		},
 	}
 
 	for i, test := range tests {
@@ -1815,7 +1861,7 @@ func TestBreakKeyword(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -2003,7 +2049,7 @@ func TestArrays(t *testing.T) {
 		if synErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Errorf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -2341,7 +2387,7 @@ func TestClasses(t *testing.T) {
 			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
 
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -2712,7 +2758,7 @@ func TestLogicalOperators(t *testing.T) {
 		if synErr != nil {
 			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
 		}
-		evaluated, runErr := evaluator.Evaluate(program, env, nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
 		if runErr != nil {
 			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
 		}
@@ -2810,3 +2856,1549 @@ func TestDestructureEvaluator(t *testing.T) {
 		}
 	}
 }
+
+func TestClassInheritance(t *testing.T) {
+	tests := []struct {
+		input  string
+		output shared.RuntimeValue
+	}{
+		{
+			input: `
+				class Animal {
+					public name
+					public constructor(n) { name = n }
+					public speak() { return name + " makes a sound" }
+				}
+				class Dog extends Animal {}
+				let d = Dog("Rex")
+				d.speak()
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "Rex makes a sound",
+			},
+		},
+		{
+			input: `
+				class Animal {
+					public name
+					public constructor(n) { name = n }
+					public speak() { return name + " makes a sound" }
+				}
+				class Dog extends Animal {
+					public breed
+					public constructor(n, b) {
+						super(n)
+						breed = b
+					}
+					public speak() { return super.speak() + ", woof" }
+				}
+				let d = Dog("Rex", "Collie")
+				d.speak() + " (" + d.breed + ")"
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "Rex makes a sound, woof (Collie)",
+			},
+		},
+		{
+			input: `
+				class Base {
+					private secret = "hidden"
+					public reveal() { return secret }
+				}
+				class Derived extends Base {
+					public peek() { return secret }
+				}
+				let d = Derived()
+				d.secret
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.Nil,
+				Value: nil,
+			},
+		},
+		{
+			input: `
+				class Base {
+					private secret = "hidden"
+				}
+				class Derived extends Base {
+					public peek() { return secret }
+				}
+				let d = Derived()
+				d.peek()
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "hidden",
+			},
+		},
+		{
+			input: `
+				class Base {
+					public value = 1
+				}
+				class Derived extends Base {
+					private value = 2
+				}
+				let d = Derived()
+				d.value
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.Nil,
+				Value: nil,
+			},
+		},
+		{
+			input: `
+				class A {
+					public depth
+					public constructor() { depth = 1 }
+				}
+				class B extends A {
+					public constructor() {
+						super()
+						depth = depth + 1
+					}
+				}
+				class C extends B {
+					public constructor() {
+						super()
+						depth = depth + 1
+					}
+				}
+				let c = C()
+				c.depth
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(3),
+			},
+		},
+		{
+			input: `
+				class Counter {
+					public count = 0
+					public inc() { count = count + 1 }
+				}
+				class Named extends Counter {
+					public get() { return count }
+				}
+				let a = Named()
+				let b = Named()
+				a.inc()
+				a.inc()
+				b.inc()
+				a.get() * 10 + b.get()
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(21),
+			},
+		},
+		{
+			input: `
+				class A {
+					public base = 1
+				}
+				fn make() {
+					let local = 5
+					class B extends A {
+						public g() { return local + base }
+					}
+					return B
+				}
+				let B = make()
+				let b = B()
+				b.g()
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(6),
+			},
+		},
+		{
+			input: `
+				fn makeParent() {
+					let label = "parent"
+					class A {
+						public parentLabel() { return label }
+					}
+					return A
+				}
+				let A = makeParent()
+				fn makeChild() {
+					let label = "child"
+					class B extends A {
+						public childLabel() { return label }
+					}
+					return B
+				}
+				let B = makeChild()
+				let b = B()
+				b.parentLabel() + " " + b.childLabel()
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "parent child",
+			},
+		},
+		{
+			// Members are bound statically: inherited methods keep calling
+			// the parent's members, even those a subclass overrides
+			input: `
+				class A {
+					public who() { return "a" }
+					public callWho() { return who() }
+				}
+				class B extends A {
+					public who() { return "b" }
+					public callOwnWho() { return who() }
+					public callSuperWho() { return super.who() }
+				}
+				let b = B()
+				b.callWho() + b.who() + b.callOwnWho() + b.callSuperWho()
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "abba",
+			},
+		},
+	}
+
+	for i, test := range tests {
+		p := parser.New("test")
+		env := environment.NewEnvironment(nil)
+		program, synErr := p.ProduceAST(test.input)
+		if synErr != nil {
+			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
+		}
+
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
+		}
+		if evaluated.Type != test.output.Type {
+			t.Errorf("test %d failed: input=%q, expected type %v, got %v", i, test.input, test.output.Type, evaluated.Type)
+		}
+		if !reflect.DeepEqual(evaluated.Value, test.output.Value) {
+			t.Errorf("test %d failed: input=%q, value mismatch. expected %v, got %v", i, test.input, test.output.Value, evaluated.Value)
+		}
+	}
+
+	errorTests := []string{
+		"let x = 1\nclass A extends x {}",
+		"fn f() { super() }\nf()",
+	}
+
+	for i, input := range errorTests {
+		p := parser.New("test")
+		env := environment.NewEnvironment(nil)
+		program, synErr := p.ProduceAST(input)
+		if synErr != nil {
+			t.Fatalf("error test %d failed: input=%q, expected no syntax error, got %v", i, input, synErr)
+		}
+		if _, runErr := testhelpers.Evaluate(t, program, env); runErr == nil {
+			t.Errorf("error test %d failed: input=%q, expected runtime error", i, input)
+		}
+	}
+}
+
+func TestForLoops(t *testing.T) {
+	tests := []struct {
+		input  string
+		output shared.RuntimeValue
+	}{
+		{
+			input: "let sum = 0\nfor (let i = 0; i < 5; i = i + 1) { sum = sum + i }\nsum",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(10),
+			},
+		},
+		{
+			input: "let i = 10\nfor (i = 0; i < 3; i = i + 1) {}\ni",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(3),
+			},
+		},
+		{
+			input: "let n = 0\nfor (;;) { n = n + 1\nif (n == 4) { break } }\nn",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(4),
+			},
+		},
+		{
+			// Like while loops, conditions other than the boolean true end the loop
+			input: "let n = 0\nfor (; 1;) { n = n + 1\nbreak }\nn",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(0),
+			},
+		},
+		{
+			input: "let odd = 0\nfor (let i = 0; i < 10; i = i + 1) { if (i % 2 == 0) { continue }\nodd = odd + 1 }\nodd",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(5),
+			},
+		},
+		{
+			input: "let fns = []\nfor (let i = 0; i < 3; i = i + 1) { let f = fn () { return i }\nfns[i] = f }\nfns[0]() + fns[1]() + fns[2]()",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(3),
+			},
+		},
+		{
+			input: "let total = 0\nfor (let x of [1, 2, 3, 4]) { total = total + x }\ntotal",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(10),
+			},
+		},
+		{
+			input: "let out = ''\nfor (const c of 'abc') { out = c + out }\nout",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "cba",
+			},
+		},
+		{
+			input: "let keys = ''\nfor (let k in {b: 1, a: 2, c: 3}) { keys = keys + k }\nkeys",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "abc",
+			},
+		},
+		{
+			input: "let arr = [5, 6, 7]\nlet sum = 0\nfor (let i in arr) { sum = sum + i * arr[i] }\nsum",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(20),
+			},
+		},
+		{
+			input: "class P {\npublic a = 1\npublic b = 2\nprivate c = 3\n}\nlet keys = ''\nfor (let k in P()) { keys = keys + k }\nkeys",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "ab",
+			},
+		},
+		{
+			input: "let found = nil\nfor (let x of [3, 8, 12, 20]) { if (x > 10) { found = x\nbreak } }\nfound",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(12),
+			},
+		},
+		{
+			input: "fn first(arr) { for (let x of arr) { return x } }\nfirst([9, 8])",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(9),
+			},
+		},
+	}
+
+	for i, test := range tests {
+		p := parser.New("test")
+		env := environment.NewEnvironment(nil)
+		env.DeclareVar("nil", values.MK_NIL(), true)
+		program, synErr := p.ProduceAST(test.input)
+		if synErr != nil {
+			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
+		}
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
+		}
+		if evaluated.Type != test.output.Type {
+			t.Errorf("test %d failed: input=%q, expected type %v, got %v", i, test.input, test.output.Type, evaluated.Type)
+		}
+		if !reflect.DeepEqual(evaluated.Value, test.output.Value) {
+			t.Errorf("test %d failed: input=%q, value mismatch. expected %v, got %v", i, test.input, test.output.Value, evaluated.Value)
+		}
+	}
+
+	errorTests := []string{
+		"for (let x of 5) {}",
+		"for (let k in 5) {}",
+		"for (const x of [1, 2]) { x = 3 }",
+	}
+
+	for i, input := range errorTests {
+		p := parser.New("test")
+		env := environment.NewEnvironment(nil)
+		program, synErr := p.ProduceAST(input)
+		if synErr != nil {
+			t.Fatalf("error test %d failed: input=%q, expected no syntax error, got %v", i, input, synErr)
+		}
+		if _, runErr := testhelpers.Evaluate(t, program, env); runErr == nil {
+			t.Errorf("error test %d failed: input=%q, expected runtime error", i, input)
+		}
+	}
+}
+
+func TestThrow(t *testing.T) {
+	tests := []struct {
+		input  string
+		output shared.RuntimeValue
+	}{
+		{
+			input: "let caught = 0\ntry { throw 42 } catch e { caught = e }\ncaught",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(42),
+			},
+		},
+		{
+			input: "let caught = ''\ntry { throw 'boom' } catch e { caught = e }\ncaught",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "boom",
+			},
+		},
+		{
+			input: "let caught = 0\ntry { throw { code: 404 } } catch e { caught = e.code }\ncaught",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(404),
+			},
+		},
+		{
+			input: `
+				class NotFound {
+					public message
+					public path
+					public constructor(p) {
+						message = "not found"
+						path = p
+					}
+				}
+				fn open(p) { throw NotFound(p) }
+				let caught = ''
+				try { open("/etc") } catch e { caught = e.message + ": " + e.path }
+				caught
+			`,
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "not found: /etc",
+			},
+		},
+		{
+			input: "let caught = ''\ntry { try { throw 'inner' } catch e { throw e + ' rethrown' } } catch e { caught = e }\ncaught",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "inner rethrown",
+			},
+		},
+	}
+
+	for i, test := range tests {
+		p := parser.New("test")
+		env := environment.NewEnvironment(nil)
+		program, synErr := p.ProduceAST(test.input)
+		if synErr != nil {
+			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
+		}
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
+		}
+		if evaluated.Type != test.output.Type {
+			t.Errorf("test %d failed: input=%q, expected type %v, got %v", i, test.input, test.output.Type, evaluated.Type)
+		}
+		if !reflect.DeepEqual(evaluated.Value, test.output.Value) {
+			t.Errorf("test %d failed: input=%q, value mismatch. expected %v, got %v", i, test.input, test.output.Value, evaluated.Value)
+		}
+	}
+
+	// uncaught values reach the host
+	uncaught := []struct {
+		input   string
+		message string
+		thrown  shared.ValueType
+	}{
+		{input: "throw 'boom'", message: "boom", thrown: shared.String},
+		{input: "throw 12.5", message: "12.5", thrown: shared.Number},
+		{input: "throw { message: 'from object' }", message: "from object", thrown: shared.Object},
+		{input: "fn f() { throw [1] }\nf()", message: "Uncaught array", thrown: shared.Array},
+	}
+
+	for i, test := range uncaught {
+		program := testhelpers.MustParse(t, test.input)
+		_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr == nil {
+			t.Fatalf("uncaught test %d failed: input=%q, expected error", i, test.input)
+		}
+		if runErr.Message != test.message {
+			t.Errorf("uncaught test %d failed: input=%q, expected message %q, got %q", i, test.input, test.message, runErr.Message)
+		}
+		if runErr.Thrown == nil || runErr.Thrown.Type != test.thrown {
+			t.Errorf("uncaught test %d failed: input=%q, expected thrown %s, got %v", i, test.input, shared.Stringify(test.thrown), runErr.Thrown)
+		}
+	}
+}
+
+func TestTryFinally(t *testing.T) {
+	tests := []struct {
+		input  string
+		output shared.RuntimeValue
+	}{
+		{
+			input: "let log = ''\ntry { log = log + 't' } catch e { log = log + 'c' } finally { log = log + 'f' }\nlog",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "tf",
+			},
+		},
+		{
+			input: "let log = ''\ntry { log = log + 't'\nthrow 'x' } catch e { log = log + 'c' } finally { log = log + 'f' }\nlog",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "tcf",
+			},
+		},
+		{
+			input: "let log = ''\ntry { try { throw 'x' } finally { log = log + 'f' } } catch e { log = log + e }\nlog",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "fx",
+			},
+		},
+		{
+			input: "let log = ''\ntry { try { throw 'x' } catch e { throw 'y' } finally { log = log + 'f' } } catch e { log = log + e }\nlog",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "fy",
+			},
+		},
+		{
+			input: "let log = ''\nfn f() { try { return 'r' } finally { log = log + 'f' } }\nf() + log",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "rf",
+			},
+		},
+		{
+			input: "fn f() { try { return 'try' } finally { return 'finally' } }\nf()",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "finally",
+			},
+		},
+		{
+			input: "let n = 0\nlet cleanups = 0\nwhile (n < 10) { n = n + 1\ntry { if (n == 3) { break } } finally { cleanups = cleanups + 1 } }\nn * 100 + cleanups",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(303),
+			},
+		},
+		{
+			input: "let sum = 0\nlet cleanups = 0\nfor (let i = 0; i < 4; i = i + 1) { try { if (i % 2 == 0) { continue }\nsum = sum + i } catch e { sum = 0 - 1 } finally { cleanups = cleanups + 1 } }\nsum * 100 + cleanups",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(404),
+			},
+		},
+		{
+			input: "let n = 0\nwhile (n < 5) { n = n + 1\ntry { break } catch e { n = 100 } }\nn",
+			output: shared.RuntimeValue{
+				Type:  shared.Number,
+				Value: float64(1),
+			},
+		},
+		{
+			input: "let log = ''\ntry { log = 't' } finally { log = log + 'f' }\nlog",
+			output: shared.RuntimeValue{
+				Type:  shared.String,
+				Value: "tf",
+			},
+		},
+	}
+
+	for i, test := range tests {
+		p := parser.New("test")
+		env := environment.NewEnvironment(nil)
+		program, synErr := p.ProduceAST(test.input)
+		if synErr != nil {
+			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, synErr)
+		}
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, expected no error, got %v", i, test.input, runErr)
+		}
+		if evaluated.Type != test.output.Type {
+			t.Errorf("test %d failed: input=%q, expected type %v, got %v", i, test.input, test.output.Type, evaluated.Type)
+		}
+		if !reflect.DeepEqual(evaluated.Value, test.output.Value) {
+			t.Errorf("test %d failed: input=%q, value mismatch. expected %v, got %v", i, test.input, test.output.Value, evaluated.Value)
+		}
+	}
+
+	// uncaught errors still run the finally block before propagating
+	program := testhelpers.MustParse(t, "let ran = 0\ntry { throw 'escaped' } finally { ran = 1 }")
+	env := environment.NewEnvironment(nil)
+	_, runErr := testhelpers.Evaluate(t, program, env)
+	if runErr == nil || runErr.Message != "escaped" {
+		t.Fatalf("expected the thrown error to propagate, got %v", runErr)
+	}
+	ran, _ := env.LookupVar("ran")
+	if ran.Value != float64(1) {
+		t.Errorf("expected finally block to run, got ran=%v", ran.Value)
+	}
+}
+
+func TestExecutionLimits(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		input string
+		opts  evaluator.Options
+		kind  errors.RuntimeErrorKind
+	}{
+		{"while (1 == 1) {}", evaluator.Options{MaxNodes: 1000}, errors.NodeLimitExceeded},
+		{"for (let i = 0; 1 == 1; i = i + 1) {}", evaluator.Options{MaxNodes: 1000}, errors.NodeLimitExceeded},
+		{"while (1 == 1) {}", evaluator.Options{Deadline: time.Now().Add(10 * time.Millisecond)}, errors.DeadlineExceeded},
+		{"while (1 == 1) {}", evaluator.Options{Context: canceled}, errors.Canceled},
+		{"fn f() { f() }\nf()", evaluator.Options{MaxCallDepth: 50}, errors.CallDepthExceeded},
+		// Scripts cannot catch limits, nor escape them with finally blocks
+		{"while (1 == 1) { try { while (1 == 1) {} } catch e {} }", evaluator.Options{MaxNodes: 1000}, errors.NodeLimitExceeded},
+		{"while (1 == 1) { try { while (1 == 1) {} } finally { continue } }", evaluator.Options{MaxNodes: 1000}, errors.NodeLimitExceeded},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		_, runErr := evaluator.EvaluateWithOptions(program, environment.NewEnvironment(nil), nil, test.opts)
+		if runErr == nil {
+			t.Fatalf("test %d failed: input=%q, expected a limit error", i, test.input)
+		}
+		// Stack overflows are the only limit scripts can catch
+		if !runErr.IsLimit() || runErr.Catchable() != (test.kind == errors.CallDepthExceeded) || runErr.Kind != test.kind {
+			t.Errorf("test %d failed: input=%q, expected error kind %v, got %v (%v)", i, test.input, test.kind, runErr.Kind, runErr)
+		}
+	}
+
+	// Limits that are not hit change nothing
+	program := testhelpers.MustParse(t, "let n = 0\nwhile (n < 10) { n = n + 1 }\nn")
+	evaluated, runErr := evaluator.EvaluateWithOptions(program, environment.NewEnvironment(nil), nil, evaluator.Options{MaxNodes: 1000, MaxCallDepth: 10, Deadline: time.Now().Add(time.Minute)})
+	if runErr != nil {
+		t.Fatalf("expected no error, got %v", runErr)
+	}
+	if evaluated.Value != float64(10) {
+		t.Errorf("expected 10, got %v", evaluated.Value)
+	}
+}
+
+func TestStackOverflow(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"fn f() { return f() }\nlet r = 0\ntry { f() } catch e { r = e.message }\nr", "Maximum call stack size exceeded"},
+		// The innermost frames involved are reported, outermost first
+		{"fn f() { return f() }\nlet r = 0\ntry { f() } catch e { r = e.stack[9].name }\nr", "f"},
+		{"fn f() { return f() }\nlet r = 0\ntry { f() } catch e { r = e.stack[9].line }\nr", float64(1)},
+		{"fn f() { return f() }\nlet r = 0\ntry { f() } catch e { r = e.stack[10] }\nr", nil},
+		{"class A {\npublic constructor() { A() }\n}\nlet r = 0\ntry { A() } catch e { r = e.stack[0].name }\nr", "constructor"},
+		// Catching the error unwinds the stack, calls work again afterwards
+		{"fn f(n) { return f(n + 1) }\nfn g(n) { if (n == 0) { return 0 } return g(n - 1) + 1 }\nlet r = 0\ntry { f(0) } catch e { r = g(100) }\nr", float64(100)},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		evaluated, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %v, got %v", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	// Uncaught, the error carries the frames along
+	program := testhelpers.MustParse(t, "fn f() { return g() }\nfn g() { return f() }\nf()")
+	_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+	if runErr == nil || runErr.Message != "Maximum call stack size exceeded" || runErr.Kind != errors.CallDepthExceeded {
+		t.Fatalf("expected a stack overflow, got %v", runErr)
+	}
+	if len(runErr.Frames) != 10 || runErr.Frames[9].Name != "f" || runErr.Frames[8].Name != "g" {
+		t.Errorf("expected the 10 innermost frames, got %v", runErr.Frames)
+	}
+
+	// The maximum depth is configurable, negative meaning no limit
+	program = testhelpers.MustParse(t, "fn f(n) { if (n == 0) { return 0 } return f(n - 1) + 1 }\nf(20)")
+	if _, runErr := evaluator.EvaluateWithOptions(program, environment.NewEnvironment(nil), nil, evaluator.Options{MaxCallDepth: 20}); runErr == nil || runErr.Kind != errors.CallDepthExceeded {
+		t.Errorf("expected a stack overflow, got %v", runErr)
+	}
+	program = testhelpers.MustParse(t, fmt.Sprintf("fn f(n) { if (n == 0) { return 0 } return f(n - 1) + 1 }\nf(%d)", evaluator.DefaultMaxCallDepth+10))
+	if _, runErr := evaluator.EvaluateWithOptions(program, environment.NewEnvironment(nil), nil, evaluator.Options{MaxCallDepth: -1}); runErr != nil {
+		t.Errorf("expected no error, got %v", runErr)
+	}
+}
+
+func TestErrorLocation(t *testing.T) {
+	tests := []struct {
+		input string
+		line  int
+	}{
+		{"let x = 1\ny", 2},
+		{"fn f(o) {\n  let a = 1\n  return o.a.b\n}\nf({})", 3},
+		{"let x = 1\nthrow \"oops\"", 2},
+		{"fn f() { f() }\n\nf()", 1},
+		{"try {\n  y\n} catch e {\n  throw e\n}", 4},
+		{"let a = 1\n\nundefinedVar + 1", 3},
+		{"let a = 1 +\n  [1]", 1},
+		{"class C {\n  public constructor() {\n    y\n  }\n}\nC()", 3},
+		{"let x = 1\ntry {\n  y\n} finally {\n  x = 2\n}", 3},
+		{"let err = ''\ntry {\n  break\n} catch e {\n  err = e\n}", 3},
+		{"fn skip() {\n  continue\n}\nlet n = 0\nwhile (n < 1) {\n  skip()\n}", 2},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr == nil {
+			t.Fatalf("test %d failed: input=%q, expected an error", i, test.input)
+		}
+		if runErr.Filename != "test" || runErr.Line != test.line {
+			t.Errorf("test %d failed: input=%q, expected the error at test:%d, got %s:%d", i, test.input, test.line, runErr.Filename, runErr.Line)
+		}
+	}
+}
+
+func TestUnaryAndBitwiseOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"-5", -5},
+		{"let x = 3\nlet y = -x\ny", -3},
+		{"-(1 + 2)", -3},
+		{"- -4", 4},
+		{"+7", 7},
+		{"2 ** 10", 1024},
+		{"2 ** -1", 0.5},
+		{"-(2 ** 2)", -4},
+		{"(-2) ** 2", 4},
+		{"2 ** 3 ** 2", 512},
+		{"(-8) ** (1 / 3)", math.NaN()},
+		{"6 & 3", 2},
+		{"6 | 3", 7},
+		{"6 ^ 3", 5},
+		{"~5", -6},
+		{"~-1", 0},
+		{"1 << 4", 16},
+		{"-16 >> 2", -4},
+		{"1 << 31", -2147483648},
+		{"1 << 32", 1},
+		{"1 << 33", 2},
+		{"4294967296 | 0", 0},
+		{"4294967295 | 0", -1},
+		{"2147483648 | 0", -2147483648},
+		{"3.7 | 0", 3},
+		{"-3.7 | 0", -3},
+		{"((-8) ** 0.5) | 0", 0},
+		{"1 + 2 << 1", 6},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		evaluated, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		got := evaluated.Value.(float64)
+		if got != test.expected && !(math.IsNaN(got) && math.IsNaN(test.expected)) {
+			t.Errorf("test %d failed: input=%q, expected %v, got %v", i, test.input, test.expected, got)
+		}
+	}
+
+	// Bitwise operators bind looser than comparisons, like in JavaScript
+	program := testhelpers.MustParse(t, "(5 & 1) == 1 && 1 << 2 > 3")
+	evaluated, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+	if runErr != nil || evaluated.Value != true {
+		t.Errorf("expected (5 & 1) == 1 && 1 << 2 > 3 to be true, got %v (%v)", evaluated, runErr)
+	}
+
+	errorTests := []struct {
+		input   string
+		message string
+	}{
+		{"-'a'", "Unary `-` can only be applied to numbers. Attempted to apply it to `string`"},
+		{"~[1]", "Unary `~` can only be applied to numbers. Attempted to apply it to `array`"},
+		{"2 ** 'a'", "This binary operation can only be performed on numbers. Attempted to perform exponentiation on `number` and `string`"},
+		{"'a' & 1", "This binary operation can only be performed on numbers. Attempted to perform bitwise `&` on `string` and `number`"},
+		{"5 & 1 == 1", "This binary operation can only be performed on numbers. Attempted to perform bitwise `&` on `number` and `boolean`"},
+	}
+
+	for i, test := range errorTests {
+		program := testhelpers.MustParse(t, test.input)
+		_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr == nil || runErr.Message != test.message {
+			t.Errorf("error test %d failed: input=%q, expected error %q, got %v", i, test.input, test.message, runErr)
+		}
+	}
+}
+
+func TestCompoundAssignment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let x = 5\nx += 2\nx", 7.0},
+		{"let x = 5\nx -= 2", 3.0},
+		{"let x = 5\nx *= 2\nx /= 4\nx", 2.5},
+		{"let x = 7\nx %= 4", 3.0},
+		{"let x = 3\nx **= 2", 9.0},
+		{"let x = 6\nx &= 3\nx |= 8\nx ^= 1\nx", 11.0},
+		{"let x = 1\nx <<= 4\nx >>= 2\nx", 4.0},
+		{"let s = 'a'\ns += 'b'\ns", "ab"},
+		{"let x = 1\nlet y = x += 2\ny + x", 6.0},
+		{"let o = { n: 1 }\no.n += 4\no.n", 5.0},
+		{"let o = { n: 1 }\no['n'] *= 3\no.n", 3.0},
+		{"let o = { n: 1 }\nlet k = 'n'\no[k] -= 1", 0.0},
+		{"let a = [1, 2]\na[1] += 10\na[1]", 12.0},
+		{"let o = { a: [1] }\no.a[0] += 1\no.a[0]", 2.0},
+		{"let x = 0\nx ??= 5\nx", 0.0},
+		{"let o = {}\no.x ??= 5\no.x", 5.0},
+		{"let x = 0\nx ||= 5\nx", 5.0},
+		{"let x = 2\nx ||= 5\nx", 2.0},
+		{"let x = 2\nx &&= 5\nx", 5.0},
+		{"let x = 0\nx &&= 5\nx", 0.0},
+		{"let o = { n: 2 }\no.n ||= 5", 2.0},
+		{"let a = [0]\na[0] &&= 5", 0.0},
+		{"let a = [0]\na[0] ||= 5\na[0]", 5.0},
+		{"const c = 1\nc ||= 2\nc", 1.0},
+		{"let calls = 0\nfn f() { calls = calls + 1\nreturn 1 }\nlet x = 1\nx ||= f()\ncalls", 0.0},
+		{"class C {\npublic n\npublic constructor() { n = 1 }\npublic add(k) { n += k\nreturn n }\n}\nlet c = C()\nc.add(2)\nc.add(3)", 6.0},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		evaluated, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %v, got %v", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	errorTests := []struct {
+		input   string
+		message string
+	}{
+		{"const c = 1\nc += 1", "Cannot reassign to constant variable `c`"},
+		{"const c = 0\nc ||= 1", "Cannot reassign to constant variable `c`"},
+		{"x += 1", "Cannot resolve variable `x`"},
+		{"let o = {}\no.n += 1", "Addition binary operation can only be performed on numbers and strings. Attempted to perform addition on `nil` and `number`"},
+		{"let a = [1]\na['x'] += 1", "Cannot assign to array using non-number index (attempted to use string)."},
+		{"let n = 1\nn.x += 1", "Cannot access property of non-object (attempting to access properties of number)."},
+	}
+
+	for i, test := range errorTests {
+		program := testhelpers.MustParse(t, test.input)
+		_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr == nil || runErr.Message != test.message {
+			t.Errorf("error test %d failed: input=%q, expected error %q, got %v", i, test.input, test.message, runErr)
+		}
+	}
+}
+
+func TestIncrementDecrement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"let x = 1\nx++\nx", 2},
+		{"let x = 1\nx++", 1},
+		{"let x = 1\n++x", 2},
+		{"let x = 1\nx--", 1},
+		{"let x = 1\n--x", 0},
+		{"let x = 1\nlet y = x++ + x\ny", 3},
+		{"let x = 1\nlet y = ++x * 10\ny", 20},
+		{"let x = 1\nlet y = -x++\ny", -1},
+		{"let x = 2\n++x ** 2", 9},
+		{"let o = { n: 1 }\no.n++", 1},
+		{"let o = { n: 1 }\no.n++\no.n", 2},
+		{"let o = { n: 1 }\n--o['n']", 0},
+		{"let a = [5]\na[0]++", 5},
+		{"let a = [5]\na[0]++\na[0]", 6},
+		{"let a = [5]\n++a[0]", 6},
+		{"let a = 1\nlet b = 1\na\n++b\nb", 2},
+		{"let sum = 0\nfor (let i = 0; i < 5; i++) { sum += i }\nsum", 10},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		evaluated, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %v, got %v", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	errorTests := []struct {
+		input   string
+		message string
+	}{
+		{"const c = 1\nc++", "Cannot reassign to constant variable `c`"},
+		{"let s = 'a'\ns++", "`++` can only be applied to numbers. Attempted to apply it to `string`"},
+		{"let o = {}\n--o.n", "`--` can only be applied to numbers. Attempted to apply it to `nil`"},
+	}
+
+	for i, test := range errorTests {
+		program := testhelpers.MustParse(t, test.input)
+		_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr == nil || runErr.Message != test.message {
+			t.Errorf("error test %d failed: input=%q, expected error %q, got %v", i, test.input, test.message, runErr)
+		}
+	}
+}
+
+func TestConditionalExpr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"1 ? 'yes' : 'no'", "yes"},
+		{"0 ? 'yes' : 'no'", "no"},
+		{"'' ? 1 : 2", 2.0},
+		{"let x = 5\nx > 3 ? x * 2 : x", 10.0},
+		{"let n = 0\nn == 0 ? 'zero' : n < 0 ? 'negative' : 'positive'", "zero"},
+		{"let n = -2\nn == 0 ? 'zero' : n < 0 ? 'negative' : 'positive'", "negative"},
+		{"let n = 2\nn == 0 ? 'zero' : n < 0 ? 'negative' : 'positive'", "positive"},
+		{"let a = 0\nlet b = 2\na || b ? 'some' : 'none'", "some"},
+		{"let a = 0\na ?? 1 ? 'set' : 'unset'", "unset"},
+		{"let x = 0\nx = 1 ? 2 : 3\nx", 2.0},
+		{"let x = 0\n0 ? x = 1 : x = 2\nx", 2.0},
+		{"let o = { v: 1 ? 'a' : 'b' }\no.v", "a"},
+		{"fn sign(n) { return n < 0 ? -1 : 1 }\nsign(-4) + sign(4) * 10", 9.0},
+		{"let i = 4\nlet j = (1 ? i : 0) + 1\nj", 5.0},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		evaluated, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %v, got %v", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	// The branch not taken is never evaluated
+	calls := map[string]int{}
+	env := environment.NewEnvironment(nil)
+	env.DeclareVar("tick", values.MK_NATIVE_FN(func(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+		calls[args[0].Value.(string)]++
+		result := values.MK_NIL()
+		return &result, nil
+	}), true)
+	program := testhelpers.MustParse(t, "1 ? tick('then') : tick('else')\n0 ? tick('then') : tick('else')\n0 ? tick('then') : 0 ? tick('then') : tick('else')")
+	if _, runErr := testhelpers.Evaluate(t, program, env); runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+	// Once per backend
+	if calls["then"] != 2 || calls["else"] != 4 {
+		t.Errorf("expected then and else to be called 2 and 4 times, got %v", calls)
+	}
+}
+
+func TestTemplateLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"``", ""},
+		{"`hello`", "hello"},
+		{"let name = 'world'\n`hello ${name}!`", "hello world!"},
+		{"let n = 3\n`${n} + ${n} = ${n + n}`", "3 + 3 = 6"},
+		{"`${0.5} ${10 ** 21} ${(-1) ** 0.5}`", "0.5 1e+21 NaN"},
+		{"`${1 == 1} ${nil}`", "true nil"},
+		{"`${[1, 'a', [2]]}`", `[1, "a", [2]]`},
+		{"`${{ b: 'x', a: 1 }}`", `{a: 1, b: "x"}`},
+		{"fn greet() {}\n`${greet}`", "<function greet>"},
+		{"class Point {}\n`${Point} ${Point()}`", "<class Point> <Point instance>"},
+		{"`a\nb`", "a\nb"},
+		{"`tab\\there \\` \\${x} $ {`", "tab\there ` ${x} $ {"},
+		{"`outer ${`inner ${1 + 1}`}`", "outer inner 2"},
+		{"`${ { k: `v${1}` } }`", `{k: "v1"}`},
+		{"let s = `${1}` + '1'\ns", "11"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		nilValue := values.MK_NIL()
+		env.DeclareVar("nil", nilValue, true)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	program := testhelpers.MustParse(t, "`a ${undefinedVar} b`")
+	_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+	if runErr == nil || runErr.Message != "Cannot resolve variable `undefinedVar`" {
+		t.Errorf("expected unresolved variable error, got %v", runErr)
+	}
+}
+
+func TestSpreadAndRest(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let a = [2, 3]\n`${[1, ...a, 4]}`", "[1, 2, 3, 4]"},
+		{"let a = [1]\n`${[...a, ...a, ...[]]}`", "[1, 1]"},
+		{"`${[...'abc']}`", `["a", "b", "c"]`},
+		{"`${[...[], 1, ...[2], 3, 4]}`", "[1, 2, 3, 4]"},
+		{"fn sum(a, b, c) { return a + b + c }\nlet args = [1, 2, 3]\n`${sum(...args)}`", "6"},
+		{"fn sum(a, b, c) { return a + b + c }\n`${sum(1, ...[2], 3)}`", "6"},
+		{"fn first(a, b) { return [a, b] }\n`${first(...[1, 2, 3])} ${first(...[1])}`", "[1, 2] [1, nil]"},
+		{"fn f(a, ...rest) { return [a, rest] }\n`${f(1, 2, 3)} ${f(1)} ${f()}`", "[1, [2, 3]] [1, []] [nil, []]"},
+		{"fn f(...all) { return all }\n`${f(...'hi', 1)}`", `["h", "i", 1]`},
+		{"let f = fn(a, ...a) { return a }\n`${f(1, 2)}`", "1"},
+		{"let o = { a: 1, b: 2 }\n`${{ ...o, b: 3, c: 4 }}`", "{a: 1, b: 3, c: 4}"},
+		{"let o = { a: 1 }\n`${{ a: 0, ...o, ...nil }}`", "{a: 1}"},
+		{"let o = { a: 1 }\nlet c = { ...o }\nc.a = 2\n`${o.a} ${c.a}`", "1 2"},
+		{"class P { public x\nprivate y\npublic constructor(v) { x = v\ny = 2 } }\n`${{ ...P(1) }}`", "{x: 1}"},
+		{"class Bag { public items\npublic constructor(...all) { items = all }\npublic count(...more) { return [items, more] } }\nlet bag = Bag(1, 2)\n`${bag.count(...[3])}`", "[[1, 2], [3]]"},
+		{"class A { public v\npublic constructor(a, b) { v = [a, b] } }\nclass B extends A { public constructor(...args) { super(...args) } }\nlet b = B(1, 2)\n`${b.v}`", "[1, 2]"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		nilValue := values.MK_NIL()
+		env.DeclareVar("nil", nilValue, true)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"[...1]", "Cannot iterate over a number (only arrays and strings are iterable)."},
+		{"fn f() {}\nf(...{ a: 1 })", "Cannot iterate over a object (only arrays and strings are iterable)."},
+		{"({ ...[1] })", "Cannot spread a array into an object (only objects and class instances can be spread)."},
+	}
+
+	for i, test := range errorTests {
+		program := testhelpers.MustParse(t, test.input)
+		_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr == nil || runErr.Message != test.expected {
+			t.Errorf("error test %d failed: input=%q, expected %q, got %v", i, test.input, test.expected, runErr)
+		}
+	}
+}
+
+func TestParamDefaultsAndPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn f(a, b = 2) { return [a, b] }\n`${f(1)} ${f(1, 3)} ${f(1, nil)}`", "[1, 2] [1, 3] [1, 2]"},
+		{"fn f(a = 1, b = a * 10) { return [a, b] }\n`${f()} ${f(2)} ${f(2, 5)}`", "[1, 10] [2, 20] [2, 5]"},
+		{"let calls = 0\nfn next() { calls += 1\nreturn calls }\nfn f(a = next()) { return a }\nf(5)\nf()\nf()\n`${calls}`", "2"},
+		{"fn f({ x, y = 2 }) { return x + y }\n`${f({ x: 1 })} ${f({ x: 1, y: 5 })}`", "3 6"},
+		{"fn f([first, , third], { a: { b } }) { return [first, third, b] }\n`${f([1, 2, 3], { a: { b: 4 } })}`", "[1, 3, 4]"},
+		{"fn f({ a, ...others } = { a: 1, b: 2 }) { return [a, others] }\n`${f()} ${f({ a: 3 })}`", "[1, {b: 2}] [3, {}]"},
+		{"fn f([a, ...more] = 'xyz', ...rest) { return [a, more, rest] }\n`${f()} ${f([1], 2, 3)}`", `["x", ["y", "z"], []] [1, [], [2, 3]]`},
+		{"fn f(a, a = 2) { return a }\n`${f(1)} ${f()}`", "1 nil"},
+		{"let base = 100\nfn f(a = base) { let base = 1\nreturn a }\n`${f()}`", "100"},
+		{"class P { public px\npublic py\npublic constructor({ x = 0, y = 0 } = {}) { px = x\npy = y } }\nlet p = P({ y: 2 })\nlet q = P()\n`${p.py} ${q.px}`", "2 0"},
+		{"class C { public scale(v, by = 2) { return v * by } }\nlet c = C()\n`${c.scale(3)} ${c.scale(3, 3)}`", "6 9"},
+		{"class A { public v\npublic constructor(init = 'a') { v = init } }\nclass B extends A { public constructor(init) { super(init) } }\nlet b = B()\n`${b.v}`", "a"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		nilValue := values.MK_NIL()
+		env.DeclareVar("nil", nilValue, true)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn f({ a }) {}\nf(1)", "Cannot destructure value of type number with an object pattern"},
+		{"fn f([a]) {}\nf()", "Cannot destructure value of type nil with an array pattern"},
+		{"fn f(a = missing) {}\nf()", "Cannot resolve variable `missing`"},
+		{"fn f(a, { a }) {}\nf(1, { a: 2 })", "Cannot redeclare variable `a`"},
+	}
+
+	for i, test := range errorTests {
+		program := testhelpers.MustParse(t, test.input)
+		_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+		if runErr == nil || runErr.Message != test.expected {
+			t.Errorf("error test %d failed: input=%q, expected %q, got %v", i, test.input, test.expected, runErr)
+		}
+	}
+}
+
+func TestArrowFn(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let double = (x) => x * 2\n`${double(21)}`", "42"},
+		{"let inc = n => n + 1\n`${inc(inc(0))}`", "2"},
+		{"let add = (a, b = 10) => { return a + b }\n`${add(1)} ${add(1, 2)}`", "11 3"},
+		{"let none = () => {}\n`${none()}`", "nil"},
+		{"let pair = ({ a }, [b]) => [a, b]\n`${pair({ a: 1 }, [2])}`", "[1, 2]"},
+		{"let wrap = () => ({ k: 1 })\nlet o = wrap()\n`${o.k}`", "1"},
+		{"let adder = a => b => a + b\nlet add2 = adder(2)\n`${add2(3)}`", "5"},
+		{"fn apply(f, v) { return f(v) }\n`${apply(x => x ** 2, 3)} ${apply((s) => `<${s}>`, 'a')}`", "9 <a>"},
+		{"fn counter() { let n = 0\nreturn () => { n += 1\nreturn n } }\nlet c = counter()\nc()\nc()\n`${c()}`", "3"},
+		{"let fns = []\nfor (let i = 0; i < 3; i++) { fns[i] = () => i }\n`${fns[0]()} ${fns[2]()}`", "0 2"},
+		{"class Box { private value\npublic constructor(v) { value = v }\npublic getter() { return () => value } }\nlet box = Box(7)\nlet get = box.getter()\n`${get()}`", "7"},
+		{"let sum = (...nums) => { let total = 0\nfor (let n of nums) { total += n }\nreturn total }\n`${sum(1, 2, 3)}`", "6"},
+		{"let cond = (x) => x > 0 ? 'pos' : 'neg'\n`${cond(1)} ${cond(-1)} ${(1 + 2) * 3}`", "pos neg 9"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		nilValue := values.MK_NIL()
+		env.DeclareVar("nil", nilValue, true)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	program := testhelpers.MustParse(t, "let f = () => missing\nf()")
+	_, runErr := testhelpers.Evaluate(t, program, environment.NewEnvironment(nil))
+	if runErr == nil || runErr.Message != "Cannot resolve variable `missing`" {
+		t.Errorf("expected unresolved variable error, got %v", runErr)
+	}
+}
+
+func TestOptionalChain(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let cfg = { db: { host: 'h' } }\n`${cfg?.db?.host} ${cfg.db?.port}`", "h nil"},
+		{"let cfg = nil\n`${cfg?.db.host.name}`", "nil"},
+		{"let list = [1, [2, 3]]\nlet none = nil\n`${list?.[1]?.[0]} ${none?.[0]}`", "2 nil"},
+		{"let o = { f: (x) => x * 2 }\n`${o.f?.(2)} ${o.g?.(2)}`", "4 nil"},
+		{"let f = nil\n`${f?.()} ${f?.()?.()}`", "nil nil"},
+		{"let o = nil\n`${o?.a ?? 'default'}`", "default"},
+		{"let o = { a: { b: nil } }\n`${o.a?.b ?? 'default'}`", "default"},
+		{"class User { public name\npublic constructor(n) { name = n } }\nlet u = User('ada')\nlet none = nil\n`${u?.name} ${none?.name}`", "ada nil"},
+		{"let calls = 0\nfn count() { calls += 1\nreturn calls }\nlet none = nil\nnone?.[count()]\nnone?.f(count())\nnone?.a.f(count())\n`${calls}`", "0"},
+		{"let o = { f: fn(a, b) { return a + b } }\n`${o?.f(...[1, 2])}`", "3"},
+		{"let o = nil\n`${(o?.a)?.b} ${(o?.a ?? { b: 1 }).b}`", "nil 1"},
+		{"let o = { a: { b: 2 } }\n`${(o?.a).b}`", "2"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		nilValue := values.MK_NIL()
+		env.DeclareVar("nil", nilValue, true)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"let o = { a: nil }\no?.a.b", "Cannot access property of non-object or non-array (attempting to access properties of nil)."},
+		{"let o = { a: 1 }\no?.a()", "Cannot invoke a non-function (attempted to call a number)."},
+		{"let o = nil\no.a?.b", "Cannot access property of non-object or non-array (attempting to access properties of nil)."},
+		{"let o = nil\nlet x = (o?.a).b", "Cannot access property of non-object or non-array (attempting to access properties of nil)."},
+		{"let o = nil\nlet x = (o?.a).b?.c", "Cannot access property of non-object or non-array (attempting to access properties of nil)."},
+		{"let o = nil\nlet x = (o?.f)()", "Cannot invoke a non-function (attempted to call a nil)."},
+	}
+
+	for i, test := range errorTests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		env.DeclareVar("nil", values.MK_NIL(), true)
+		_, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr == nil || runErr.Message != test.expected {
+			t.Errorf("error test %d failed: input=%q, expected %q, got %v", i, test.input, test.expected, runErr)
+		}
+	}
+}
+
+func TestSwitchStmt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn name(n) { let out = 'other'\nswitch (n) { case 1: out = 'one'\ncase 2, 3: out = 'few'\ndefault: out = 'many' }\nreturn out }\n`${name(1)} ${name(3)} ${name(9)}`", "one few many"},
+		{"let out = 'none'\nswitch ('b') { case 'a': out = 'a' }\nout", "none"},
+		{"let out = ''\nswitch (2) { default: out = 'default'\ncase 2: out = 'two' }\nout", "two"},
+		{"let out = ''\nswitch (1) { case 1: out += 'a'\nbreak\nout += 'b'\ncase 2: out += 'c' }\nout", "a"},
+		{"let out = ''\nfor (let i = 0; i < 4; i++) { switch (i) { case 1: continue\ncase 2: break } \nout += `${i}` }\nout", "023"},
+		{"let out = ''\nlet i = 0\nwhile (i < 3) { i++\nswitch (i) { case 2: try { break } catch e { out += 'caught' } }\nout += `${i}` }\nout", "123"},
+		{"let x = 'outer'\nswitch (1) { case 1: let x = 'inner' }\nx", "outer"},
+		{"let calls = 0\nfn value(v) { calls += 1\nreturn v }\nswitch (1) { case value(1), value(2): calls += 10 }\n`${calls}`", "11"},
+		{"let o = {}\nlet out = ''\nswitch (o) { case {}: out = 'copy'\ncase o: out = 'same' }\nout", "same"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+}
+
+func TestMatchExpr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn size(n) { return match (n) { case 0 => 'none', case 1, 2 => 'few', default => 'many' } }\n`${size(0)} ${size(2)} ${size(5)}`", "none few many"},
+		{"fn area(s) { return match (s) { case { w, h } => w * h\ncase { r } => 3 * r * r } }\n`${area({ w: 2, h: 3 })} ${area({ r: 2 })}`", "6 12"},
+		{"fn head(l) { return match (l) { case [x] => `one ${x}`\ncase [x, ...rest] => `${x} then ${rest[0]}`\ndefault => 'empty' } }\n`${head([])} ${head([1])} ${head([1, 2, 3])}`", "empty one 1 1 then 2"},
+		{"fn sign(n) { return match (n) { case 0 => 'zero'\ndefault if n < 0 => 'negative'\ncase 1 => 'one' } }\n`${sign(0)} ${sign(-4)} ${sign(1)} ${sign(4)}`", "zero negative one nil"},
+		{"fn kind(e) { return match (e) { case { type, value } if type == 'num' => value\ncase { type, name } if type == 'var' => name\ndefault => '?' } }\n`${kind({ type: 'num', value: 4 })} ${kind({ type: 'var', name: 'x' })} ${kind({ type: 'num' })}`", "4 x ?"},
+		{"let p = { pos: [1, 2] }\nmatch (p) { case { pos: [x, y] } => `${x},${y}` }", "1,2"},
+		{"let p = { pos: [1, 2, 3] }\nmatch (p) { case { pos: [x, y] } => 'flat', case { pos: [x, ...more] } => `${more[1]}` }", "3"},
+		{"match ([1]) { case [a, b = 5] => `${a + b}` }", "6"},
+		{"match ('ab') { case [a, b] => 'array', default => 'string' }", "string"},
+		{"let x = 'outer'\nlet y = match ({ x: 'inner' }) { case { x } => x }\n`${x} ${y}`", "outer inner"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		nilValue := values.MK_NIL()
+		env.DeclareVar("nil", nilValue, true)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+}
+
+func TestDoWhileLoop(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let out = ''\nlet i = 0\ndo { out += `${i}`\ni++ } while (i < 3)\nout", "012"},
+		{"let runs = 0\ndo { runs++ } while (false)\n`${runs}`", "1"},
+		{"let out = ''\nlet i = 0\ndo { i++\nif (i == 2) { continue }\nif (i == 4) { break }\nout += `${i}` } while (i < 10)\nout", "13"},
+		{"let attempts = 0\ndo { attempts++ } while (attempts < 3)\n`${attempts}`", "3"},
+		{"let x = 'outer'\ndo { let x = 'inner' } while (false)\nx", "outer"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		env.DeclareVar("true", values.MK_BOOL(true), true)
+		env.DeclareVar("false", values.MK_BOOL(false), true)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+}
+
+func TestLabeledLoops(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let out = ''\nouter: for (let i = 0; i < 3; i++) { for (let j = 0; j < 3; j++) { if (j == 1) { continue outer }\nout += `${i}${j} ` } }\nout", "00 10 20 "},
+		{"let out = ''\nouter: for (let i = 0; i < 3; i++) { for (let j = 0; j < 3; j++) { if (i == 1) { break outer }\nout += `${i}${j} ` } }\nout", "00 01 02 "},
+		{"let out = ''\nrows: for (const row of [[1, 2], [3, 4]]) { for (const cell of row) { if (cell == 2) { continue rows }\nif (cell == 4) { break rows }\nout += `${cell}` } }\nout", "13"},
+		{"let out = ''\nkeys: for (const k in { a: 1, b: 2 }) { let i = 0\nwhile (true) { i++\nif (i > 1) { continue keys }\nout += k } }\nout", "ab"},
+		{"let i = 0\nlet out = ''\nloop: do { i++\nswitch (i) { case 2: continue loop\ncase 4: break loop }\nout += `${i}` } while (true)\nout", "13"},
+		{"let out = ''\nlet i = 0\nouter: while (i < 2) { i++\ninner: while (true) { try { break outer } catch e { out += 'caught' } } }\n`${out}${i}`", "1"},
+		{"let out = ''\na: for (let i = 0; i < 2; i++) { out += `${i}` }\na: for (let i = 0; i < 2; i++) { out += `${i}` }\nout", "0101"},
+		{"let i = 0\nlet out = ''\nouter: while (i < 3) { i++\nlet f = fn() { for (let j = 0; j < 3; j++) { if (j == 1) { break }\nout += `${j}` } }\nf() }\nout", "000"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		env.DeclareVar("true", values.MK_BOOL(true), true)
+		env.DeclareVar("false", values.MK_BOOL(false), true)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+}
+
+func TestBlockStmt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 'outer'\n{ let x = 'inner'\nconst y = x }\nx", "outer"},
+		{"let x = 1\n{ x = 2 }\n`${x}`", "2"},
+		{"let out = ''\n{ const tmp = 'a'\n{ const tmp = 'b'\nout += tmp }\nout += tmp }\nout", "ba"},
+		{"let out = ''\nfor (let i = 0; i < 3; i++) { { if (i == 1) { continue }\nout += `${i}` } }\nout", "02"},
+		{"let fns = []\nfor (let i = 0; i < 2; i++) { { const j = i * 10\nfns = [...fns, () => j] } }\n`${fns[0]()} ${fns[1]()}`", "0 10"},
+		{"fn f() { { return 'early' }\nreturn 'late' }\nf()", "early"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	program := testhelpers.MustParse(t, "{ let hidden = 1 }\nhidden")
+	env := environment.NewEnvironment(nil)
+	if _, runErr := testhelpers.Evaluate(t, program, env); runErr == nil {
+		t.Errorf("expected a variable declared in a block not to leak out of it")
+	}
+}
+
+func TestTypeInspectionOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"typeof 1 == 'number'", true},
+		{"typeof 'a' == 'string'", true},
+		{"typeof [] == 'array'", true},
+		{"typeof ({}) == 'object'", true},
+		{"typeof {} == 'object'", true},
+		{"let t = typeof { a: 1 }\nt == 'object'", true},
+		{"typeof nil == 'nil'", true},
+		{"typeof (x => x) == 'function'", true},
+		{"typeof(1 + 1) == 'number'", true},
+		{"typeof typeof 1 == 'string'", true},
+		{"class A {}\ntypeof A == 'class' && typeof A() == 'class-instance'", true},
+		{"class A {}\nA() instanceof A", true},
+		{"class A {}\nclass B {}\nA() instanceof B", false},
+		{"class A {}\nclass B extends A {}\nB() instanceof A", true},
+		{"class A {}\nclass B extends A {}\nA() instanceof B", false},
+		{"class A {}\nlet Alias = A\nA() instanceof Alias", true},
+		{"class A {}\n{} instanceof A", false},
+		{"fn make() { class Local {}\nreturn Local }\nlet First = make()\nlet Second = make()\nFirst() instanceof Second", false},
+		{"let First = nil\nlet Second = nil\nlet i = 0\nwhile (i < 2) {\nclass L {}\nif (i == 0) { First = L } else { Second = L }\ni = i + 1\n}\nFirst() instanceof Second", false},
+		{"class A {}\nclass B extends A {}\nlet Alias = A\nB() instanceof Alias", true},
+		{"'a' in { a: 1 }", true},
+		{"'b' in { a: 1 }", false},
+		{"'a' in { a: nil }", true},
+		{"1 in [1, 2]", true},
+		{"2 in [1, 2]", false},
+		{"-1 in [1, 2]", false},
+		{"0.5 in [1, 2]", false},
+		{"'0' in [1, 2]", false},
+		{"class P { public name = 'x'\nprivate secret = 'y' }\n'name' in P()", true},
+		{"class P { public name = 'x'\nprivate secret = 'y' }\n'secret' in P()", false},
+		{"let o = { k: 1 }\nlet out = 0\nfor (const key in o) { if (key in o) { out += 1 } }\nout == 1", true},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		env.DeclareVar("nil", values.MK_NIL(), true)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Type != shared.Boolean || evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %v, got %v", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"1 instanceof 1", "The right-hand side of `instanceof` must be a class, got number."},
+		{"'a' in 'abc'", "Cannot use `in` to look for a key in a string (only objects, arrays and class instances have keys)."},
+	}
+
+	for i, test := range errorTests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		_, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr == nil || runErr.Message != test.expected {
+			t.Errorf("error test %d failed: input=%q, expected %q, got %v", i, test.input, test.expected, runErr)
+		}
+	}
+}
+
+func TestStaticClassMembers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"class C { public static count = 1 }\n`${C.count}`", "1"},
+		{"class C { public static double(n) { return n * 2 } }\n`${C.double(2)}`", "4"},
+		{"class C { public static a() { return 'a' }\npublic static b() { return a() + 'b' } }\nC.b()", "ab"},
+		{"class C { private static count = 0\npublic static next() { count += 1\nreturn count } }\nC.next()\nC.next()\n`${C.next()}`", "3"},
+		{"class C { private static count = 0 }\ntypeof C.count", "nil"},
+		{"class C { public static v = 1 }\ntypeof C['v']", "number"},
+		{"class C { public static v = 1 }\nlet c = C()\ntypeof c.v", "nil"},
+		{"class C { public static v = 'hi'\npublic get() { return C.v } }\nlet c = C()\nc.get()", "hi"},
+		{"let runs = 0\nfn tick() { runs += 1\nreturn runs }\nclass C { public static v = tick()\npublic x = 1 }\nC()\nC()\n`${runs} ${C.v}`", "1 1"},
+		{"class A { public static v = 'a' }\nclass B extends A { public static w = v + 'b' }\n`${B.v} ${B.w}`", "a ab"},
+		{"class A { public static v = 'a' }\nclass B extends A { private static v = 'b' }\ntypeof B.v", "nil"},
+		{"class A { public static x = 1 }\nfn make() { let local = 5\nclass B extends A { public static y = local + x }\nreturn B }\nlet B = make()\n`${B.y}`", "6"},
+		{"class A {}\nfn make() { let local = 'l'\nclass B extends A { public static get() { return local } }\nreturn B }\nlet B = make()\nB.get()", "l"},
+		{"let v = 'outer'\nclass A { public static v = 'a' }\nclass B extends A { public static w = v }\nB.w", "a"},
+		{"class C { public static n = 1\npublic static read() { return n } }\nC.n = 5\n`${C.n} ${C.read()}`", "5 5"},
+		{"class C { public static n = 1 }\nC['n'] += 2\nC.n++\n`${C.n}`", "4"},
+		{"class C { public static n = 1 }\nlet Alias = C\nAlias.n = 2\n`${C.n}`", "2"},
+		{"class A { public static n = 1 }\nclass B extends A {}\nB.n = 3\n`${A.n} ${B.n}`", "3 3"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"class C { private static n = 1 }\nC.n = 2", "Cannot assign to private member `n` of class `C`."},
+		{"class C {}\nC.n = 2", "Cannot assign to `n`, class `C` has no such member."},
+		{"class C { public static n = 1 }\nC[1] = 2", "Cannot access property of class instance by non-string (attempting to access properties by number)."},
+	}
+
+	for i, test := range errorTests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		_, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr == nil || runErr.Message != test.expected {
+			t.Errorf("error test %d failed: input=%q, expected %q, got %v", i, test.input, test.expected, runErr)
+		}
+	}
+}
+
+func TestClassAccessors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"class R { public w = 2\npublic h = 3\npublic get area() { return w * h } }\nlet r = R()\n`${r.area}`", "6"},
+		{"class T { private c = 0\npublic get f() { return c * 9 / 5 + 32 }\npublic set f(v) { c = (v - 32) * 5 / 9 }\npublic get celsius() { return c } }\nlet t = T()\nt.f = 212\n`${t.celsius}`", "100"},
+		{"class C { private v = 1\npublic get x() { return v }\npublic set x(n) { v = n } }\nlet c = C()\nc.x += 4\nc.x++\n`${c.x}`", "6"},
+		{"class C { private v = 1\npublic set x(n) { v = n * 2 }\npublic get x() { return v } }\nlet c = C()\n`${c.x = 5} ${c.x}`", "5 10"},
+		{"class C { private v = 1\npublic get x() { return v }\npublic set x(n) { v = n } }\nlet c = C()\nc['x'] = 7\n`${c['x']}`", "7"},
+		{"class C { public set x(n) {} }\nlet c = C()\ntypeof c.x", "nil"},
+		{"class A { public get who() { return 'a' } }\nclass B extends A {}\nlet b = B()\nb.who", "a"},
+		{"class A { public get who() { return 'a' } }\nclass B extends A { public get who() { return super.who + 'b' } }\nlet b = B()\nb.who", "ab"},
+		{"class C { public n = 1\npublic get x() { return 2 } }\nlet c = C()\nlet out = ''\nfor (const k in c) { out += k }\nout", "n"},
+		{"class C { public get() { return 'g' }\npublic set = 's' }\nlet c = C()\nc.get() + c.set", "gs"},
+		{"class P { public name = 'a' }\nlet p = P()\np.name = 'b'\np.name", "b"},
+		{"class P { public n = 1\npublic read() { return n } }\nlet p = P()\np.n = 5\n`${p.read()}`", "5"},
+		{"class P { public n = 1 }\nlet p = P()\np['n'] += 2\n`${p.n}`", "3"},
+		{"class A { public n = 1 }\nclass B extends A {}\nlet b = B()\nb.n = 3\n`${b.n}`", "3"},
+		{"class P { public n = 1 }\nlet a = P()\nlet b = P()\na.n = 2\n`${a.n} ${b.n}`", "2 1"},
+	}
+
+	for i, test := range tests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		evaluated, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr != nil {
+			t.Fatalf("test %d failed: input=%q, unexpected error: %v", i, test.input, runErr)
+		}
+		if evaluated.Value != test.expected {
+			t.Errorf("test %d failed: input=%q, expected %q, got %q", i, test.input, test.expected, evaluated.Value)
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"class C { private s = 1 }\nlet c = C()\nc.s = 2", "Cannot assign to private member `s` of class `C`."},
+		{"class C { private s = 1 }\nlet c = C()\nc.s += 2", "Cannot assign to private member `s` of class `C`."},
+		{"class C {}\nlet c = C()\nc.x = 2", "Cannot assign to `x`, class `C` has no such member."},
+		{"class C { public get x() { return 1 } }\nlet c = C()\nc.x = 2", "Cannot assign to `x` of class `C`, it only has a getter."},
+		{"class A { public get x() { return 1 }\npublic set x(v) {} }\nclass B extends A { public get x() { return 2 } }\nlet b = B()\nb.x = 2", "Cannot assign to `x` of class `B`, it only has a getter."},
+		{"class C { public n = 1 }\nlet c = C()\nc[1] = 2", "Cannot access property of class instance by non-string (attempting to access properties by number)."},
+	}
+
+	for i, test := range errorTests {
+		program := testhelpers.MustParse(t, test.input)
+		env := environment.NewEnvironment(nil)
+		_, runErr := testhelpers.Evaluate(t, program, env)
+		if runErr == nil || runErr.Message != test.expected {
+			t.Errorf("error test %d failed: input=%q, expected %q, got %v", i, test.input, test.expected, runErr)
+		}
+	}
+
+	// Getters show in stack traces, called from the member access reading them
+	program := testhelpers.MustParse(t, "class C { public get x() { return c.x } }\nlet c = C()\nc.x")
+	env := environment.NewEnvironment(nil)
+	_, runErr := testhelpers.Evaluate(t, program, env)
+	if runErr == nil || runErr.Kind != errors.CallDepthExceeded {
+		t.Fatalf("expected the getter to overflow the stack, got %v", runErr)
+	}
+	if frame := runErr.Frames[len(runErr.Frames)-1]; frame.Name != "x" || frame.Line != 1 {
+		t.Errorf("expected the innermost frame to be the getter called on line 1, got %+v", frame)
+	}
+}
@@ -0,0 +1,142 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+// Options limits the resources an evaluation may use. The zero value of each
+// field means no limit, except for MaxCallDepth which falls back to
+// DefaultMaxCallDepth so that runaway recursion cannot crash the host.
+type Options struct {
+	MaxNodes     int             // Maximum number of nodes evaluated, or of instructions executed by the vm
+	MaxCallDepth int             // Maximum number of nested function and constructor calls, negative for no limit
+	Deadline     time.Time       // Time after which the evaluation is aborted
+	Context      context.Context // The evaluation is aborted once the context is done
+}
+
+// DefaultMaxCallDepth is the call depth allowed when Options.MaxCallDepth is
+// zero, well within what the Go stack can hold
+const DefaultMaxCallDepth = 10000
+
+// stackTraceLimit is the number of innermost frames a stack overflow error
+// reports
+const stackTraceLimit = 10
+
+// CallDepth returns the maximum call depth the options allow, 0 meaning no
+// limit
+func (o Options) CallDepth() int {
+	if o.MaxCallDepth == 0 {
+		return DefaultMaxCallDepth
+	}
+	if o.MaxCallDepth < 0 {
+		return 0
+	}
+	return o.MaxCallDepth
+}
+
+// StackOverflow returns the error raised when a call would exceed the maximum
+// call depth. frames holds the calls in progress, outermost first, including
+// the one refused.
+func StackOverflow(frames []errors.StackFrame) *errors.RuntimeError {
+	if len(frames) > stackTraceLimit {
+		frames = frames[len(frames)-stackTraceLimit:]
+	}
+	return &errors.RuntimeError{
+		Message: "Maximum call stack size exceeded",
+		Kind:    errors.CallDepthExceeded,
+		Frames:  append([]errors.StackFrame(nil), frames...),
+	}
+}
+
+// Budget tracks the resources used by a run against its Options. The
+// evaluator charges it one step per node evaluated and the vm one step per
+// instruction executed, unit naming what a step is in error messages.
+type Budget struct {
+	opts     Options
+	unit     string
+	interval int
+	steps    int
+	// Calls in progress, tracked apart from the debugger's call stack
+	frames []errors.StackFrame
+	// Once a limit is hit every later check fails with the same error, so
+	// finally blocks and loops cannot keep the run going. Stack overflows
+	// are not sticky, unwinding the stack recovers from them
+	err *errors.RuntimeError
+}
+
+// NewBudget returns a budget enforcing opts, checking the deadline and the
+// context every interval steps
+func NewBudget(opts Options, unit string, interval int) *Budget {
+	return &Budget{opts: opts, unit: unit, interval: interval}
+}
+
+// interruptInterval is the number of nodes evaluated between two checks of
+// the deadline and the context
+const interruptInterval = 256
+
+// newExecution returns the budget of an evaluation
+func newExecution(opts Options) *Budget {
+	return NewBudget(opts, "evaluated nodes", interruptInterval)
+}
+
+// Step accounts for one step of the run
+func (b *Budget) Step() *errors.RuntimeError {
+	if b.err != nil {
+		return b.err
+	}
+
+	b.steps++
+	if b.opts.MaxNodes > 0 && b.steps > b.opts.MaxNodes {
+		b.err = &errors.RuntimeError{
+			Message: fmt.Sprintf("Execution exceeded the limit of %d %s.", b.opts.MaxNodes, b.unit),
+			Kind:    errors.NodeLimitExceeded,
+		}
+		return b.err
+	}
+
+	if b.steps%b.interval == 0 {
+		return b.Interrupted()
+	}
+	return nil
+}
+
+// Interrupted checks the deadline and the context
+func (b *Budget) Interrupted() *errors.RuntimeError {
+	if b.err != nil {
+		return b.err
+	}
+
+	if ctx := b.opts.Context; ctx != nil && ctx.Err() != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			b.err = &errors.RuntimeError{Message: "Execution timed out.", Kind: errors.DeadlineExceeded}
+		} else {
+			b.err = &errors.RuntimeError{Message: "Execution was canceled.", Kind: errors.Canceled}
+		}
+	} else if !b.opts.Deadline.IsZero() && time.Now().After(b.opts.Deadline) {
+		b.err = &errors.RuntimeError{Message: "Execution timed out.", Kind: errors.DeadlineExceeded}
+	}
+	return b.err
+}
+
+// EnterCall accounts for a function or constructor call, which must be
+// balanced by ExitCall once it returns
+func (b *Budget) EnterCall(frame errors.StackFrame) *errors.RuntimeError {
+	if err := b.Interrupted(); err != nil {
+		return err
+	}
+
+	if max := b.opts.CallDepth(); max > 0 && len(b.frames) >= max {
+		return StackOverflow(append(b.frames[:len(b.frames):len(b.frames)], frame))
+	}
+
+	b.frames = append(b.frames, frame)
+	return nil
+}
+
+func (b *Budget) ExitCall() {
+	b.frames = b.frames[:len(b.frames)-1]
+}
@@ -0,0 +1,188 @@
+package evaluator
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/debugger"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// buildClassScope evaluates the members of a class in a scope on top of the
+// one the class was declared in. Inherited members are forwarded to the scope
+// of the parent, so they resolve unqualified before the declaration scope and
+// share their variables with the parent's methods. The returned map holds every
+// member name in the chain and whether it is public; members redeclared by a
+// subclass take the subclass' visibility. Getters and setters are returned
+// apart, as they are not variables of the scope.
+//
+// Members are bound statically, like any other name: a method resolves the
+// members it uses in the scope of the class declaring it, so an inherited
+// method keeps using the parent's version of a member the subclass overrides.
+func buildClassScope(classVal *values.ClassValue, dbgr *debugger.Debugger, exec *Budget) (*environment.Environment, map[string]bool, map[string]values.Accessor, *errors.RuntimeError) {
+	classScope := environment.NewEnvironment(classVal.DeclarationEnv)
+	publics := map[string]bool{}
+	accessors := map[string]values.Accessor{}
+
+	var superVal *shared.RuntimeValue
+	if classVal.Parent != nil {
+		parentScope, parentPublics, parentAccessors, err := buildClassScope(classVal.Parent, dbgr, exec)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// `super` sees every member of the parent, private ones included,
+		// since those are already reachable from the subclass unqualified
+		superPublics := map[string]bool{}
+		for name, isPublic := range parentPublics {
+			publics[name] = isPublic
+			superPublics[name] = true
+			classScope.Forward(name, parentScope)
+		}
+		for name, accessor := range parentAccessors {
+			accessors[name] = accessor
+		}
+
+		super := instanceWithAccessors(values.MK_CLASS_INSTANCE(classVal.Parent, superPublics, parentScope), parentAccessors)
+		superVal = &super
+	}
+
+	if superVal != nil {
+		if _, err := classScope.DeclareVar("super", *superVal, true); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	// A getter or setter replaces both accessors of an inherited property
+	redefined := map[string]bool{}
+	for _, stmt := range classVal.Body {
+		if stmt.GetType() == ast.ClassMethodNode {
+			method := stmt.(*ast.ClassMethod)
+			if method.IsStatic {
+				continue
+			}
+			if method.Accessor != "" {
+				if !redefined[method.Name] {
+					accessors[method.Name] = values.Accessor{}
+					redefined[method.Name] = true
+				}
+				accessors[method.Name] = accessors[method.Name].With(method.Accessor, classMethodValue(method, classScope))
+				continue
+			}
+			_, err := evalClassMethod(method, classScope)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			publics[method.Name] = method.IsPublic
+			delete(accessors, method.Name)
+		} else if stmt.GetType() == ast.ClassPropertyNode {
+			property := stmt.(*ast.ClassProperty)
+			if property.IsStatic {
+				continue
+			}
+			_, err := evalClassProperty(property, classScope, dbgr, exec)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			publics[property.Name] = property.IsPublic
+			delete(accessors, property.Name)
+		}
+	}
+
+	return classScope, publics, accessors, nil
+}
+
+// instanceWithAccessors attaches getters and setters to a class instance
+func instanceWithAccessors(instance shared.RuntimeValue, accessors map[string]values.Accessor) shared.RuntimeValue {
+	instanceVal := instance.Value.(values.ClassInstanceValue)
+	instanceVal.Accessors = accessors
+	instance.Value = instanceVal
+	return instance
+}
+
+// parentScopeOf returns the scope of the parent built along with a subclass
+// scope, held by its `super`
+func parentScopeOf(classScope *environment.Environment) *environment.Environment {
+	super, _ := classScope.LookupVar("super")
+	return super.Value.(values.ClassInstanceValue).Data
+}
+
+// runConstructor invokes the constructor of a class against a scope produced by
+// buildClassScope. Subclasses without a constructor of their own use the
+// nearest ancestor's, run against that ancestor's scope.
+func runConstructor(classVal *values.ClassValue, classScope *environment.Environment, args []*shared.RuntimeValue, node *ast.CallExpr, dbgr *debugger.Debugger, exec *Budget) *errors.RuntimeError {
+	for classVal.Constructor == nil && classVal.Parent != nil {
+		classVal = classVal.Parent
+		classScope = parentScopeOf(classScope)
+	}
+
+	if classVal.Constructor == nil {
+		return &errors.RuntimeError{
+			Message: "Class has no constructor.",
+		}
+	}
+
+	frame := errors.StackFrame{
+		Name:     "constructor",
+		Filename: node.GetSourceMetadata().Filename,
+		Line:     node.GetSourceMetadata().StartLine,
+	}
+	if err := exec.EnterCall(frame); err != nil {
+		return err
+	}
+	defer exec.ExitCall()
+
+	constructor := classVal.Constructor
+	constructorScope := environment.NewEnvironment(classScope)
+	if err := bindParams(constructorScope, &constructor.Signature, args, dbgr, exec); err != nil {
+		return err
+	}
+
+	// Push frame to stack
+	if dbgr != nil {
+		dbgr.PushFrame(debugger.StackFrame(frame))
+	}
+
+	for _, stmt := range constructor.Body {
+		_, err := evaluate(stmt, constructorScope, dbgr, exec)
+		if err != nil {
+			// Take snapshot and pop frame from stack
+			if dbgr != nil {
+				dbgr.TakeSnapshot()
+				dbgr.PopFrame()
+			}
+			if err.InternalCommunicationProtocol != nil && err.InternalCommunicationProtocol.Type == errors.ICP_Return {
+				return &errors.RuntimeError{
+					Message: "Constructor cannot return a value.",
+				}
+			}
+			return strayControlFlow(err)
+		}
+	}
+
+	// Pop frame from stack
+	if dbgr != nil {
+		dbgr.PopFrame()
+	}
+
+	return nil
+}
+
+// evalSuperCall runs the parent constructor for `super(...)` inside a subclass.
+func evalSuperCall(node *ast.CallExpr, args []*shared.RuntimeValue, env *environment.Environment, dbgr *debugger.Debugger, exec *Budget) (*shared.RuntimeValue, *errors.RuntimeError) {
+	super, err := env.LookupVar("super")
+	if err != nil || super.Type != shared.ClassInstance {
+		return nil, &errors.RuntimeError{
+			Message: "`super` can only be called inside a class that extends another class.",
+		}
+	}
+
+	parent := super.Value.(values.ClassInstanceValue)
+	if err := runConstructor(&parent.Class, parent.Data, args, node, dbgr, exec); err != nil {
+		return nil, err
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
@@ -2,9 +2,11 @@ package helpers
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
 )
 
 func TestIsTruthy(t *testing.T) {
@@ -196,3 +198,66 @@ func TestIsTruthy(t *testing.T) {
 		})
 	}
 }
+
+func TestToString(t *testing.T) {
+	circular := values.MK_OBJECT(map[string]*shared.RuntimeValue{})
+	circular.Value.(map[string]*shared.RuntimeValue)["self"] = &circular
+
+	testCases := []struct {
+		name     string
+		value    *shared.RuntimeValue
+		expected string
+	}{
+		{"NilPointer", nil, "nil"},
+		{"Nil", &shared.RuntimeValue{Type: shared.Nil}, "nil"},
+		{"String", &shared.RuntimeValue{Type: shared.String, Value: "hi"}, "hi"},
+		{"Integer", &shared.RuntimeValue{Type: shared.Number, Value: float64(42)}, "42"},
+		{"Fraction", &shared.RuntimeValue{Type: shared.Number, Value: float64(-0.25)}, "-0.25"},
+		{"Large", &shared.RuntimeValue{Type: shared.Number, Value: float64(1e21)}, "1e+21"},
+		{"Small", &shared.RuntimeValue{Type: shared.Number, Value: float64(1e-7)}, "1e-07"},
+		{"NaN", &shared.RuntimeValue{Type: shared.Number, Value: math.NaN()}, "NaN"},
+		{"Infinity", &shared.RuntimeValue{Type: shared.Number, Value: math.Inf(-1)}, "-Infinity"},
+		{"Boolean", &shared.RuntimeValue{Type: shared.Boolean, Value: true}, "true"},
+		{"Array", &shared.RuntimeValue{Type: shared.Array, Value: []shared.RuntimeValue{
+			values.MK_NUMBER(1), values.MK_STRING("a"), values.MK_ARRAY([]shared.RuntimeValue{}),
+		}}, `[1, "a", []]`},
+		{"Object", &shared.RuntimeValue{Type: shared.Object, Value: map[string]*shared.RuntimeValue{
+			"b": {Type: shared.String, Value: "x"},
+			"a": {Type: shared.Nil},
+		}}, `{a: nil, b: "x"}`},
+		{"CircularObject", &circular, "{self: [Circular]}"},
+		{"Function", &shared.RuntimeValue{Type: shared.Function, Value: &values.FunctionValue{Name: "add"}}, "<function add>"},
+		{"NativeFunction", &shared.RuntimeValue{Type: shared.NativeFN}, "<native function>"},
+		{"Class", &shared.RuntimeValue{Type: shared.Class, Value: values.ClassValue{Name: "Person"}}, "<class Person>"},
+		{"ClassInstance", &shared.RuntimeValue{Type: shared.ClassInstance, Value: values.ClassInstanceValue{Class: values.ClassValue{Name: "Person"}}}, "<Person instance>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ToString(tc.value); got != tc.expected {
+				t.Errorf("ToString() = %q; want %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestInspect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    *shared.RuntimeValue
+		expected string
+	}{
+		{"NilPointer", nil, "nil"},
+		{"String", &shared.RuntimeValue{Type: shared.String, Value: "hi\n"}, `"hi\n"`},
+		{"Number", &shared.RuntimeValue{Type: shared.Number, Value: float64(1)}, "1"},
+		{"Array", &shared.RuntimeValue{Type: shared.Array, Value: []shared.RuntimeValue{values.MK_STRING("a")}}, `["a"]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Inspect(tc.value); got != tc.expected {
+				t.Errorf("Inspect() = %q; want %q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package helpers
+
+import "github.com/dev-kas/virtlang-go/v4/shared"
+
+// Inspect renders a VirtLang RuntimeValue like ToString, except that strings
+// are quoted at the top level too. It suits displaying the result of an
+// expression, where "1" and 1 must read differently.
+func Inspect(value *shared.RuntimeValue) string {
+	return formatValue(value, map[uintptr]bool{})
+}
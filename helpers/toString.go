@@ -0,0 +1,128 @@
+package helpers
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// ToString converts a VirtLang RuntimeValue into its string representation.
+//
+// Conversion rules:
+// - String: the string itself
+// - Number: shortest decimal form (1, 0.5, 1e+21), NaN, Infinity, -Infinity
+// - Boolean: true or false
+// - Nil: nil
+// - Array: [1, 2, "three"], strings nested in containers are quoted
+// - Object: {a: 1, b: "two"}, keys sorted
+// - Function: <function name>
+// - NativeFN: <native function>
+// - Class: <class Name>
+// - ClassInstance: <Name instance>
+//
+// Arrays and objects that contain themselves are rendered as [Circular].
+func ToString(value *shared.RuntimeValue) string {
+	if value == nil {
+		return "nil"
+	}
+	if value.Type == shared.String {
+		return value.Value.(string)
+	}
+	return formatValue(value, map[uintptr]bool{})
+}
+
+func formatValue(value *shared.RuntimeValue, seen map[uintptr]bool) string {
+	if value == nil {
+		return "nil"
+	}
+
+	switch value.Type {
+	case shared.Nil:
+		return "nil"
+
+	case shared.Boolean:
+		return strconv.FormatBool(value.Value.(bool))
+
+	case shared.Number:
+		return formatNumber(value.Value.(float64))
+
+	case shared.String:
+		return strconv.Quote(value.Value.(string))
+
+	case shared.Array:
+		elements := value.Value.([]shared.RuntimeValue)
+		ptr := reflect.ValueOf(elements).Pointer()
+		if len(elements) > 0 && seen[ptr] {
+			return "[Circular]"
+		}
+		seen[ptr] = true
+		defer delete(seen, ptr)
+
+		parts := make([]string, len(elements))
+		for i := range elements {
+			parts[i] = formatValue(&elements[i], seen)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+
+	case shared.Object:
+		properties := value.Value.(map[string]*shared.RuntimeValue)
+		ptr := reflect.ValueOf(properties).Pointer()
+		if seen[ptr] {
+			return "[Circular]"
+		}
+		seen[ptr] = true
+		defer delete(seen, ptr)
+
+		keys := make([]string, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = key + ": " + formatValue(properties[key], seen)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+
+	case shared.Function:
+		if fn, ok := value.Value.(*values.FunctionValue); ok && fn.Name != "" {
+			return "<function " + fn.Name + ">"
+		}
+		return "<function>"
+
+	case shared.NativeFN:
+		return "<native function>"
+
+	case shared.Class:
+		return "<class " + value.Value.(values.ClassValue).Name + ">"
+
+	case shared.ClassInstance:
+		return "<" + value.Value.(values.ClassInstanceValue).Class.Name + " instance>"
+
+	default:
+		return "<" + shared.Stringify(value.Type) + ">"
+	}
+}
+
+func formatNumber(num float64) string {
+	switch {
+	case math.IsNaN(num):
+		return "NaN"
+	case math.IsInf(num, 1):
+		return "Infinity"
+	case math.IsInf(num, -1):
+		return "-Infinity"
+	}
+
+	abs := math.Abs(num)
+	if abs != 0 && (abs >= 1e21 || abs < 1e-6) {
+		return strconv.FormatFloat(num, 'g', -1, 64)
+	}
+	return strconv.FormatFloat(num, 'f', -1, 64)
+}
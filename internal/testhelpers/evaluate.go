@@ -0,0 +1,81 @@
+package testhelpers
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/compiler"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/evaluator"
+	"github.com/dev-kas/virtlang-go/v4/helpers"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/vm"
+)
+
+// Evaluate evaluates a program in env with the tree-walking evaluator and
+// returns its result. The program is also compiled and run by the vm against
+// a copy of env, failing the test unless both backends agree on the result,
+// the error, its location and the variables left in the environment.
+func Evaluate(t *testing.T, program *ast.Program, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	t.Helper()
+	vmEnv := environment.DeepCopy(env)
+
+	evaluated, evalErr := evaluator.Evaluate(program, env, nil)
+
+	fn, synErr := compiler.Compile(program)
+	if synErr != nil {
+		t.Errorf("vm: compile error: %v", synErr)
+		return evaluated, evalErr
+	}
+	ran, vmErr := vm.Run(fn, vmEnv)
+
+	switch {
+	case (evalErr == nil) != (vmErr == nil):
+		t.Errorf("vm: error mismatch: evaluator returned %v, vm returned %v", evalErr, vmErr)
+	case evalErr != nil && evalErr.Message != vmErr.Message:
+		t.Errorf("vm: error mismatch: evaluator returned %q, vm returned %q", evalErr.Message, vmErr.Message)
+	case evalErr != nil && (evalErr.Filename != vmErr.Filename || evalErr.Line != vmErr.Line):
+		t.Errorf("vm: location mismatch: evaluator reported %s:%d, vm reported %s:%d", evalErr.Filename, evalErr.Line, vmErr.Filename, vmErr.Line)
+	case evalErr != nil && !reflect.DeepEqual(evalErr.Frames, vmErr.Frames):
+		t.Errorf("vm: frames mismatch: evaluator reported %v, vm reported %v", evalErr.Frames, vmErr.Frames)
+	case evalErr == nil && !sameValue(evaluated, ran):
+		t.Errorf("vm: result mismatch: evaluator returned %s, vm returned %s", describe(evaluated), describe(ran))
+	}
+
+	names := map[string]struct{}{}
+	for name := range env.Variables {
+		names[name] = struct{}{}
+	}
+	for name := range vmEnv.Variables {
+		names[name] = struct{}{}
+	}
+	sorted := []string{}
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		if !sameValue(env.Variables[name], vmEnv.Variables[name]) {
+			t.Errorf("vm: variable `%s` mismatch: evaluator left %s, vm left %s", name, describe(env.Variables[name]), describe(vmEnv.Variables[name]))
+		}
+	}
+
+	return evaluated, evalErr
+}
+
+func sameValue(a, b *shared.RuntimeValue) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Type == b.Type && helpers.ToString(a) == helpers.ToString(b)
+}
+
+func describe(value *shared.RuntimeValue) string {
+	if value == nil {
+		return "nothing"
+	}
+	return shared.Stringify(value.Type) + " " + helpers.ToString(value)
+}
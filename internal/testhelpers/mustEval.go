@@ -4,16 +4,16 @@ import (
 	"testing"
 
 	"github.com/dev-kas/virtlang-go/v4/environment"
-	"github.com/dev-kas/virtlang-go/v4/evaluator"
 	"github.com/dev-kas/virtlang-go/v4/shared"
 )
 
-// MustEval evaluates source code fully (parse -> eval) and returns the value and environment
+// MustEval evaluates source code fully (parse -> eval) and returns the value and environment.
+// The vm runs the program too and must agree with the evaluator.
 func MustEval(t *testing.T, src string) (*shared.RuntimeValue, *environment.Environment) {
 	t.Helper()
 	prog := MustParse(t, src)
 	env := environment.NewEnvironment(nil)
-	val, err := evaluator.Evaluate(prog, env, nil)
+	val, err := Evaluate(t, prog, env)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -8,7 +8,7 @@ import (
 )
 
 // MustParse parses source code and returns AST program or fails the test
-func MustParse(t *testing.T, src string) *ast.Program {
+func MustParse(t testing.TB, src string) *ast.Program {
 	t.Helper()
 	p := parser.New("test")
 	prog, err := p.ProduceAST(src)
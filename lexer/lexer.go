@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/dev-kas/virtlang-go/v4/errors"
 )
@@ -15,7 +16,7 @@ const (
 	Number          TokenType = iota // 0 - 9
 	Identifier                       // a - z A - Z 0 - 9 _ $
 	Equals                           // =
-	BinOperator                      // / * + _
+	BinOperator                      // + - * / % ** & | ^ ~ << >>
 	OParen                           // (
 	CParen                           // )
 	Let                              // let
@@ -44,6 +45,28 @@ const (
 	Public                           // public
 	Private                          // private
 	LogicalOperator                  // && || ?? !
+	Extends                          // extends
+	Super                            // super
+	For                              // for
+	In                               // in
+	Throw                            // throw
+	Finally                          // finally
+	AssignOperator                   // += -= *= /= %= **= &= |= ^= <<= >>= ??= ||= &&=
+	UpdateOperator                   // ++ --
+	QuestionMark                     // ?
+	Backtick                         // `
+	TemplateString                   // Text of a template literal
+	Interpolation                    // ${
+	Arrow                            // =>
+	OptionalChain                    // ?.
+	Switch                           // switch
+	Case                             // case
+	Default                          // default
+	Match                            // match
+	Do                               // do
+	TypeOf                           // typeof
+	InstanceOf                       // instanceof
+	Static                           // static
 	EOF                              // end of file
 )
 
@@ -113,6 +136,50 @@ func Stringify(t TokenType) string {
 		return "Private"
 	case LogicalOperator:
 		return "LogicalOperator"
+	case Extends:
+		return "Extends"
+	case Super:
+		return "Super"
+	case For:
+		return "For"
+	case In:
+		return "In"
+	case Throw:
+		return "Throw"
+	case Finally:
+		return "Finally"
+	case AssignOperator:
+		return "AssignOperator"
+	case UpdateOperator:
+		return "UpdateOperator"
+	case QuestionMark:
+		return "QuestionMark"
+	case Backtick:
+		return "Backtick"
+	case TemplateString:
+		return "TemplateString"
+	case Interpolation:
+		return "Interpolation"
+	case Arrow:
+		return "Arrow"
+	case OptionalChain:
+		return "OptionalChain"
+	case Switch:
+		return "Switch"
+	case Case:
+		return "Case"
+	case Default:
+		return "Default"
+	case Match:
+		return "Match"
+	case Do:
+		return "Do"
+	case TypeOf:
+		return "TypeOf"
+	case InstanceOf:
+		return "InstanceOf"
+	case Static:
+		return "Static"
 	case EOF:
 		return "EOF"
 	default:
@@ -121,20 +188,34 @@ func Stringify(t TokenType) string {
 }
 
 var KEYWORDS = map[string]TokenType{
-	"let":      Let,
-	"const":    Const,
-	"fn":       Fn,
-	"if":       If,
-	"else":     Else,
-	"while":    WhileLoop,
-	"try":      Try,
-	"catch":    Catch,
-	"return":   Return,
-	"break":    Break,
-	"continue": Continue,
-	"class":    Class,
-	"public":   Public,
-	"private":  Private,
+	"let":        Let,
+	"const":      Const,
+	"fn":         Fn,
+	"if":         If,
+	"else":       Else,
+	"while":      WhileLoop,
+	"try":        Try,
+	"catch":      Catch,
+	"return":     Return,
+	"break":      Break,
+	"continue":   Continue,
+	"class":      Class,
+	"public":     Public,
+	"private":    Private,
+	"extends":    Extends,
+	"super":      Super,
+	"for":        For,
+	"in":         In,
+	"throw":      Throw,
+	"finally":    Finally,
+	"switch":     Switch,
+	"case":       Case,
+	"default":    Default,
+	"match":      Match,
+	"do":         Do,
+	"typeof":     TypeOf,
+	"instanceof": InstanceOf,
+	"static":     Static,
 }
 
 var REVERSE_KEYWORDS = make(map[TokenType]string, len(KEYWORDS))
@@ -182,7 +263,29 @@ func IsSkippable(r rune) bool {
 
 func IsBinaryOperator(r rune) bool {
 	switch r {
-	case '+', '-', '*', '/', '%':
+	case '+', '-', '*', '/', '%', '&', '|', '^', '~':
+		return true
+	default:
+		return false
+	}
+}
+
+func IsAssignmentOperator(r string) bool {
+	switch r {
+	case "+=", "-=", "*=", "/=", "%=", "**=", "&=", "|=", "^=", "<<=", ">>=", "??=", "||=", "&&=":
+		return true
+	default:
+		return false
+	}
+}
+
+func IsUpdateOperator(r string) bool {
+	return r == "++" || r == "--"
+}
+
+func IsTwoCharBinaryOperator(r string) bool {
+	switch r {
+	case "**", "<<", ">>":
 		return true
 	default:
 		return false
@@ -217,6 +320,31 @@ func UnescapeString(s string) (string, error) {
 	return fmt.Sprintf("\"%s\"", stringQuoted), nil
 }
 
+// unescapeTemplate resolves the escape sequences in the text of a template
+// literal, which may also escape backticks and dollar signs
+func unescapeTemplate(s string) (string, error) {
+	var sb strings.Builder
+	for len(s) > 0 {
+		if len(s) > 1 && s[0] == '\\' && strings.IndexByte("`$'\"", s[1]) >= 0 {
+			sb.WriteByte(s[1])
+			s = s[2:]
+			continue
+		}
+
+		value, multibyte, tail, err := strconv.UnquoteChar(s, 0)
+		if err != nil {
+			return "", err
+		}
+		if value < utf8.RuneSelf || !multibyte {
+			sb.WriteByte(byte(value))
+		} else {
+			sb.WriteRune(value)
+		}
+		s = tail
+	}
+	return sb.String(), nil
+}
+
 func IsComparisonOperator(r string) bool {
 	switch r {
 	case "==", ">", "<", "!=", "<=", ">=":
@@ -257,6 +385,91 @@ func Tokenize(srcCode string) ([]Token, *errors.LexerError) {
 		',': Comma,
 	}
 
+	// Template literals being lexed, innermost last. Outside of their text,
+	// the innermost one is always lexing an interpolation, which the `}` found
+	// at its brace depth closes
+	type openTemplate struct {
+		start      errors.Position
+		braceDepth int
+	}
+	var templates []openTemplate
+	braceDepth := 0
+
+	// scanTemplate lexes the text of the innermost template literal up to its
+	// closing backtick or its next interpolation
+	scanTemplate := func() *errors.LexerError {
+		template := &templates[len(templates)-1]
+		textStartLine, textStartCol := currentLine, currentColumn
+		var text strings.Builder
+
+		emitText := func() *errors.LexerError {
+			if text.Len() == 0 {
+				return nil
+			}
+			literal, err := unescapeTemplate(text.String())
+			if err != nil {
+				return &errors.LexerError{
+					Character: '`',
+					Pos:       errors.Position{Line: textStartLine, Col: textStartCol},
+					Message:   err.Error(),
+				}
+			}
+			tokens = append(tokens, NewToken(literal, TemplateString, textStartLine, textStartCol, currentLine, currentColumn))
+			return nil
+		}
+
+		for position < srcLen {
+			r := runes[position]
+
+			switch {
+			case r == '`':
+				if err := emitText(); err != nil {
+					return err
+				}
+				position++
+				currentColumn++
+				tokens = append(tokens, NewToken("`", Backtick, currentLine, currentColumn-1, currentLine, currentColumn))
+				templates = templates[:len(templates)-1]
+				return nil
+
+			case r == '$' && position+1 < srcLen && runes[position+1] == '{':
+				if err := emitText(); err != nil {
+					return err
+				}
+				position += 2
+				currentColumn += 2
+				tokens = append(tokens, NewToken("${", Interpolation, currentLine, currentColumn-2, currentLine, currentColumn))
+				template.braceDepth = braceDepth
+				return nil
+
+			case r == '\\' && position+1 < srcLen && runes[position+1] != '\n' && runes[position+1] != '\r':
+				text.WriteRune(r)
+				text.WriteRune(runes[position+1])
+				position += 2
+				currentColumn += 2
+
+			case r == '\n' || r == '\r':
+				text.WriteRune('\n')
+				position++
+				if r == '\r' && position < srcLen && runes[position] == '\n' { // Check for \r\n
+					position++
+				}
+				currentLine++
+				currentColumn = 1
+
+			default:
+				text.WriteRune(r)
+				position++
+				currentColumn++
+			}
+		}
+
+		return &errors.LexerError{
+			Character: '`',
+			Pos:       template.start,
+		}
+	}
+
 	for position < srcLen {
 		tokStartLine := currentLine
 		tokStartCol := currentColumn
@@ -284,15 +497,42 @@ func Tokenize(srcCode string) ([]Token, *errors.LexerError) {
 			continue
 		}
 
-		// --- 2. Single-character punctuation ---
+		// --- 2. Template Literals (`...${expr}...`) ---
+		if currentCharRune == '`' {
+			position++
+			currentColumn++
+			tokens = append(tokens, NewToken("`", Backtick, tokStartLine, tokStartCol, currentLine, currentColumn))
+			templates = append(templates, openTemplate{start: errors.Position{Line: tokStartLine, Col: tokStartCol}})
+			if err := scanTemplate(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if currentCharRune == '}' && len(templates) > 0 && templates[len(templates)-1].braceDepth == braceDepth {
+			position++
+			currentColumn++
+			tokens = append(tokens, NewToken("}", CBrace, tokStartLine, tokStartCol, currentLine, currentColumn))
+			if err := scanTemplate(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// --- 3. Single-character punctuation ---
 		if tokenType, ok := singleCharTokenMap[currentCharRune]; ok {
 			position++      // Consume the rune
 			currentColumn++ // These characters don't cause line breaks
+			switch currentCharRune {
+			case '{':
+				braceDepth++
+			case '}':
+				braceDepth--
+			}
 			tokens = append(tokens, NewToken(string(currentCharRune), tokenType, tokStartLine, tokStartCol, currentLine, currentColumn))
 			continue
 		}
 
-		// --- 3. Comments (// and /* */) ---
+		// --- 4. Comments (// and /* */) ---
 		if currentCharRune == '/' {
 			if position+1 < srcLen {
 				nextRune := runes[position+1]
@@ -361,15 +601,51 @@ func Tokenize(srcCode string) ([]Token, *errors.LexerError) {
 			}
 		}
 
-		// --- 4. Binary Operators (standalone +, -, *, /, %) ---
-		if IsBinaryOperator(currentCharRune) {
+		// --- 5. Assignment and Update Operators (+=, <<=, ++, ...), longest first ---
+		if length := func() int {
+			for length := 3; length >= 2; length-- {
+				if position+length <= srcLen {
+					op := string(runes[position : position+length])
+					if IsAssignmentOperator(op) || IsUpdateOperator(op) {
+						return length
+					}
+				}
+			}
+			return 0
+		}(); length > 0 {
+			op := string(runes[position : position+length])
+			tokenType := AssignOperator
+			if IsUpdateOperator(op) {
+				tokenType = UpdateOperator
+			}
+			position += length
+			currentColumn += length
+			tokens = append(tokens, NewToken(op, tokenType, tokStartLine, tokStartCol, currentLine, currentColumn))
+			continue
+		}
+
+		// --- 6. Binary Operators (+, -, *, /, %, **, &, |, ^, ~, <<, >>) ---
+		if position+1 < srcLen && IsTwoCharBinaryOperator(string(runes[position:position+2])) {
+			position += 2
+			currentColumn += 2
+			tokens = append(tokens, NewToken(string(runes[position-2:position]), BinOperator, tokStartLine, tokStartCol, currentLine, currentColumn))
+			continue
+		}
+		// && and || are logical operators
+		if IsBinaryOperator(currentCharRune) && (position+1 >= srcLen || !IsLogicalOperator(string(runes[position:position+2]))) {
 			position++
 			currentColumn++
 			tokens = append(tokens, NewToken(string(currentCharRune), BinOperator, tokStartLine, tokStartCol, currentLine, currentColumn))
 			continue
 		}
 
-		// --- 5. Comparison and Equals Operators (=, ==, >, <, !=, <=, >=) ---
+		// --- 7. Arrow, Comparison and Equals Operators (=>, =, ==, >, <, !=, <=, >=) ---
+		if currentCharRune == '=' && position+1 < srcLen && runes[position+1] == '>' {
+			position += 2
+			currentColumn += 2
+			tokens = append(tokens, NewToken("=>", Arrow, tokStartLine, tokStartCol, currentLine, currentColumn))
+			continue
+		}
 		if strings.ContainsRune("=<>!", currentCharRune) {
 			firstOpCharStr := string(currentCharRune)
 
@@ -397,7 +673,7 @@ func Tokenize(srcCode string) ([]Token, *errors.LexerError) {
 			}
 		}
 
-		// --- 6. Logical Operators (&&, ||, ??, !) ---
+		// --- 8. Logical Operators (&&, ||, ??, !) ---
 		if twoCharacterOperator := ""; func() bool {
 			if position+1 < srcLen {
 				twoCharacterOperator = string([]rune{currentCharRune, runes[position+1]})
@@ -416,7 +692,24 @@ func Tokenize(srcCode string) ([]Token, *errors.LexerError) {
 			continue
 		}
 
-		// --- 7. String Literals ('...' or "...") ---
+		// `?.`, unless it is a `?` followed by a number like `.5`
+		if currentCharRune == '?' && position+1 < srcLen && runes[position+1] == '.' &&
+			(position+2 >= srcLen || !isDecimalDigit(runes[position+2])) {
+			position += 2
+			currentColumn += 2
+			tokens = append(tokens, NewToken("?.", OptionalChain, tokStartLine, tokStartCol, currentLine, currentColumn))
+			continue
+		}
+
+		// A lone `?`, after `??` and `?.` had their chance
+		if currentCharRune == '?' {
+			position++
+			currentColumn++
+			tokens = append(tokens, NewToken("?", QuestionMark, tokStartLine, tokStartCol, currentLine, currentColumn))
+			continue
+		}
+
+		// --- 9. String Literals ('...' or "...") ---
 		if currentCharRune == '\'' || currentCharRune == '"' {
 			quoteRune := currentCharRune
 
@@ -426,16 +719,18 @@ func Tokenize(srcCode string) ([]Token, *errors.LexerError) {
 			unclosedStringErrorPos := errors.Position{Line: tokStartLine, Col: tokStartCol}
 			var strContentBuilder strings.Builder // Builds the content BETWEEN quotes
 			foundEndQuote := false
+			escaped := false // Whether the previous rune is a backslash escaping this one
 
 			for position < srcLen {
 				loopRune := runes[position] // Current rune from source
 
-				if loopRune == quoteRune && runes[position-1] != '\\' { // Not an escaped quote
+				if loopRune == quoteRune && !escaped { // Not an escaped quote
 					position++      // Consume the closing quote from runes
 					currentColumn++ // The closing quote itself advances column
 					foundEndQuote = true
 					break
 				}
+				escaped = loopRune == '\\' && !escaped
 
 				strContentBuilder.WriteRune(loopRune) // Add the current rune to our string's content
 				position++                            // Consume the rune from source
@@ -485,61 +780,31 @@ func Tokenize(srcCode string) ([]Token, *errors.LexerError) {
 			continue
 		}
 
-		// --- 8. Numbers (integers, floats, including dot-prefixed like .5) ---
-		if currentCharRune == '.' {
-			if position+1 < srcLen && IsNumeric(runes[position+1]) { // Number like .5
-				numStartIndex := position
-				position++ // Consume '.'
-				currentColumn++
-
-				for position < srcLen && IsNumeric(runes[position]) {
-					position++
-					currentColumn++
-				}
-				literal := string(runes[numStartIndex:position])
-				tokens = append(tokens, NewToken(literal, Number, tokStartLine, tokStartCol, currentLine, currentColumn))
-				continue
-			} else { // Just a Dot token
-				position++
-				currentColumn++
-				tokens = append(tokens, NewToken(".", Dot, tokStartLine, tokStartCol, currentLine, currentColumn))
-				continue
+		// --- 10. Numbers (decimal, 0x, 0b, 0o, with exponents and separators) ---
+		// A dot after another dot is never a number, so `...1` spreads 1
+		startsFraction := currentCharRune == '.' && position+1 < srcLen && isDecimalDigit(runes[position+1]) &&
+			(position == 0 || runes[position-1] != '.')
+		if IsNumeric(currentCharRune) || startsFraction {
+			end, err := scanNumber(runes, position, tokStartLine, tokStartCol)
+			if err != nil {
+				return nil, err
 			}
+			literal := string(runes[position:end])
+			currentColumn += end - position
+			position = end
+			tokens = append(tokens, NewToken(literal, Number, tokStartLine, tokStartCol, currentLine, currentColumn))
+			continue
 		}
 
-		if IsNumeric(currentCharRune) {
-			numStartIndex := position
-			hasDecimal := false
-			for position < srcLen {
-				loopRune := runes[position]
-
-				if IsNumeric(loopRune) {
-					position++
-					currentColumn++
-				} else if loopRune == '.' {
-					if hasDecimal {
-						return nil, &errors.LexerError{
-							Character: '.',
-							Pos:       errors.Position{Line: currentLine, Col: currentColumn},
-						}
-					}
-					if position+1 < srcLen && IsNumeric(runes[position+1]) {
-						hasDecimal = true
-						position++ // Consume '.'
-						currentColumn++
-					} else { // Number ends before '.', e.g., "123."
-						break
-					}
-				} else { // Not a digit or a valid part of a number
-					break
-				}
-			}
-			literal := string(runes[numStartIndex:position])
-			tokens = append(tokens, NewToken(literal, Number, tokStartLine, tokStartCol, currentLine, currentColumn))
+		// A dot not starting a number
+		if currentCharRune == '.' {
+			position++
+			currentColumn++
+			tokens = append(tokens, NewToken(".", Dot, tokStartLine, tokStartCol, currentLine, currentColumn))
 			continue
 		}
 
-		// --- 9. Identifiers and Keywords ---
+		// --- 11. Identifiers and Keywords ---
 		if IsAlpha(currentCharRune) || currentCharRune == '_' || currentCharRune == '$' {
 			identStartIndex := position
 
@@ -561,13 +826,21 @@ func Tokenize(srcCode string) ([]Token, *errors.LexerError) {
 			continue
 		}
 
-		// --- 10. Unrecognized Character ---
+		// --- 12. Unrecognized Character ---
 		return nil, &errors.LexerError{
 			Character: currentCharRune,
 			Pos:       errors.Position{Line: tokStartLine, Col: tokStartCol},
 		}
 	} // End of main for loop
 
+	// A template literal still lexing an interpolation
+	if len(templates) > 0 {
+		return nil, &errors.LexerError{
+			Character: '`',
+			Pos:       templates[len(templates)-1].start,
+		}
+	}
+
 	// --- EOF Token ---
 	tokens = append(tokens, NewToken("<EOF>", EOF, currentLine, currentColumn, currentLine, currentColumn))
 	return tokens, nil
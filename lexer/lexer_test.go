@@ -1,6 +1,7 @@
 package lexer_test
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -117,6 +118,16 @@ func TestTokenize(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "Escaped Backslash Before Closing Quote",
+			input: `"a\\" 'b\\'`,
+			want: []lexer.Token{
+				lexer.NewToken("a\\", lexer.String, 1, 1, 1, 6),
+				lexer.NewToken("b\\", lexer.String, 1, 7, 1, 12),
+				lexer.NewToken("<EOF>", lexer.EOF, 1, 12, 1, 12),
+			},
+			wantErr: false,
+		},
 		{
 			name:  "Unicode Escape Sequence",
 			input: `"\u2388 <- UNICODE"`,
@@ -177,6 +188,90 @@ func TestTokenize(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "Exponent, Bitwise and Shift Operators",
+			input: "a**2 << 1 >> b & ~c | d ^ e && f",
+			want: []lexer.Token{
+				lexer.NewToken("a", lexer.Identifier, 1, 1, 1, 2),
+				lexer.NewToken("**", lexer.BinOperator, 1, 2, 1, 4),
+				lexer.NewToken("2", lexer.Number, 1, 4, 1, 5),
+				lexer.NewToken("<<", lexer.BinOperator, 1, 6, 1, 8),
+				lexer.NewToken("1", lexer.Number, 1, 9, 1, 10),
+				lexer.NewToken(">>", lexer.BinOperator, 1, 11, 1, 13),
+				lexer.NewToken("b", lexer.Identifier, 1, 14, 1, 15),
+				lexer.NewToken("&", lexer.BinOperator, 1, 16, 1, 17),
+				lexer.NewToken("~", lexer.BinOperator, 1, 18, 1, 19),
+				lexer.NewToken("c", lexer.Identifier, 1, 19, 1, 20),
+				lexer.NewToken("|", lexer.BinOperator, 1, 21, 1, 22),
+				lexer.NewToken("d", lexer.Identifier, 1, 23, 1, 24),
+				lexer.NewToken("^", lexer.BinOperator, 1, 25, 1, 26),
+				lexer.NewToken("e", lexer.Identifier, 1, 27, 1, 28),
+				lexer.NewToken("&&", lexer.LogicalOperator, 1, 29, 1, 31),
+				lexer.NewToken("f", lexer.Identifier, 1, 32, 1, 33),
+				lexer.NewToken("<EOF>", lexer.EOF, 1, 33, 1, 33),
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Assignment and Update Operators",
+			input: "a += b **= c ??= d++ - --e",
+			want: []lexer.Token{
+				lexer.NewToken("a", lexer.Identifier, 1, 1, 1, 2),
+				lexer.NewToken("+=", lexer.AssignOperator, 1, 3, 1, 5),
+				lexer.NewToken("b", lexer.Identifier, 1, 6, 1, 7),
+				lexer.NewToken("**=", lexer.AssignOperator, 1, 8, 1, 11),
+				lexer.NewToken("c", lexer.Identifier, 1, 12, 1, 13),
+				lexer.NewToken("??=", lexer.AssignOperator, 1, 14, 1, 17),
+				lexer.NewToken("d", lexer.Identifier, 1, 18, 1, 19),
+				lexer.NewToken("++", lexer.UpdateOperator, 1, 19, 1, 21),
+				lexer.NewToken("-", lexer.BinOperator, 1, 22, 1, 23),
+				lexer.NewToken("--", lexer.UpdateOperator, 1, 24, 1, 26),
+				lexer.NewToken("e", lexer.Identifier, 1, 26, 1, 27),
+				lexer.NewToken("<EOF>", lexer.EOF, 1, 27, 1, 27),
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Conditional Operator",
+			input: "a ?? b ? c : d",
+			want: []lexer.Token{
+				lexer.NewToken("a", lexer.Identifier, 1, 1, 1, 2),
+				lexer.NewToken("??", lexer.LogicalOperator, 1, 3, 1, 5),
+				lexer.NewToken("b", lexer.Identifier, 1, 6, 1, 7),
+				lexer.NewToken("?", lexer.QuestionMark, 1, 8, 1, 9),
+				lexer.NewToken("c", lexer.Identifier, 1, 10, 1, 11),
+				lexer.NewToken(":", lexer.Colon, 1, 12, 1, 13),
+				lexer.NewToken("d", lexer.Identifier, 1, 14, 1, 15),
+				lexer.NewToken("<EOF>", lexer.EOF, 1, 15, 1, 15),
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Template Literal",
+			input: "`a ${ {b: `c${d}`}.b } \\`\n$`",
+			want: []lexer.Token{
+				lexer.NewToken("`", lexer.Backtick, 1, 1, 1, 2),
+				lexer.NewToken("a ", lexer.TemplateString, 1, 2, 1, 4),
+				lexer.NewToken("${", lexer.Interpolation, 1, 4, 1, 6),
+				lexer.NewToken("{", lexer.OBrace, 1, 7, 1, 8),
+				lexer.NewToken("b", lexer.Identifier, 1, 8, 1, 9),
+				lexer.NewToken(":", lexer.Colon, 1, 9, 1, 10),
+				lexer.NewToken("`", lexer.Backtick, 1, 11, 1, 12),
+				lexer.NewToken("c", lexer.TemplateString, 1, 12, 1, 13),
+				lexer.NewToken("${", lexer.Interpolation, 1, 13, 1, 15),
+				lexer.NewToken("d", lexer.Identifier, 1, 15, 1, 16),
+				lexer.NewToken("}", lexer.CBrace, 1, 16, 1, 17),
+				lexer.NewToken("`", lexer.Backtick, 1, 17, 1, 18),
+				lexer.NewToken("}", lexer.CBrace, 1, 18, 1, 19),
+				lexer.NewToken(".", lexer.Dot, 1, 19, 1, 20),
+				lexer.NewToken("b", lexer.Identifier, 1, 20, 1, 21),
+				lexer.NewToken("}", lexer.CBrace, 1, 22, 1, 23),
+				lexer.NewToken(" `\n$", lexer.TemplateString, 1, 23, 2, 2),
+				lexer.NewToken("`", lexer.Backtick, 2, 2, 2, 3),
+				lexer.NewToken("<EOF>", lexer.EOF, 2, 3, 2, 3),
+			},
+			wantErr: false,
+		},
 		{
 			name:  "Multi-line Comment and Whitespace",
 			input: "  let x = 10; /* Multi\n line \n comment */ const y = 20;",
@@ -227,8 +322,7 @@ func TestTokenize(t *testing.T) {
 				lexer.NewToken("try", lexer.Try, 1, 15, 1, 18),
 				lexer.NewToken("c$", lexer.Identifier, 1, 19, 1, 21),
 				lexer.NewToken("=", lexer.Equals, 1, 22, 1, 23),
-				lexer.NewToken("1", lexer.Number, 1, 24, 1, 25),
-				lexer.NewToken("_000", lexer.Identifier, 1, 25, 1, 29),
+				lexer.NewToken("1_000", lexer.Number, 1, 24, 1, 29),
 				lexer.NewToken(";", lexer.SemiColon, 1, 29, 1, 30),
 				lexer.NewToken("<EOF>", lexer.EOF, 1, 30, 1, 30),
 			},
@@ -283,6 +377,18 @@ func TestTokenize(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:    "Error Unclosed Template Literal",
+			input:   "`a ${b}",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Error Unclosed Interpolation",
+			input:   "`a ${b",
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "Error Unclosed Multi-line Comment",
 			input:   `let x = /* start`,
@@ -336,6 +442,72 @@ func TestTokenize(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "Prefixed, Scientific and Separated Numbers",
+			input: "0xFF 0b1010 0O755 1e9 2.5E-3 .5e+2 1_000_000 0xdead_BEEF",
+			want: []lexer.Token{
+				lexer.NewToken("0xFF", lexer.Number, 1, 1, 1, 5),
+				lexer.NewToken("0b1010", lexer.Number, 1, 6, 1, 12),
+				lexer.NewToken("0O755", lexer.Number, 1, 13, 1, 18),
+				lexer.NewToken("1e9", lexer.Number, 1, 19, 1, 22),
+				lexer.NewToken("2.5E-3", lexer.Number, 1, 23, 1, 29),
+				lexer.NewToken(".5e+2", lexer.Number, 1, 30, 1, 35),
+				lexer.NewToken("1_000_000", lexer.Number, 1, 36, 1, 45),
+				lexer.NewToken("0xdead_BEEF", lexer.Number, 1, 46, 1, 57),
+				lexer.NewToken("<EOF>", lexer.EOF, 1, 57, 1, 57),
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Arrow Function",
+			input: "(a) => a >= 1",
+			want: []lexer.Token{
+				lexer.NewToken("(", lexer.OParen, 1, 1, 1, 2),
+				lexer.NewToken("a", lexer.Identifier, 1, 2, 1, 3),
+				lexer.NewToken(")", lexer.CParen, 1, 3, 1, 4),
+				lexer.NewToken("=>", lexer.Arrow, 1, 5, 1, 7),
+				lexer.NewToken("a", lexer.Identifier, 1, 8, 1, 9),
+				lexer.NewToken(">=", lexer.ComOperator, 1, 10, 1, 12),
+				lexer.NewToken("1", lexer.Number, 1, 13, 1, 14),
+				lexer.NewToken("<EOF>", lexer.EOF, 1, 14, 1, 14),
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Optional Chaining",
+			input: "a?.b ?.5:c?.[0]",
+			want: []lexer.Token{
+				lexer.NewToken("a", lexer.Identifier, 1, 1, 1, 2),
+				lexer.NewToken("?.", lexer.OptionalChain, 1, 2, 1, 4),
+				lexer.NewToken("b", lexer.Identifier, 1, 4, 1, 5),
+				lexer.NewToken("?", lexer.QuestionMark, 1, 6, 1, 7),
+				lexer.NewToken(".5", lexer.Number, 1, 7, 1, 9),
+				lexer.NewToken(":", lexer.Colon, 1, 9, 1, 10),
+				lexer.NewToken("c", lexer.Identifier, 1, 10, 1, 11),
+				lexer.NewToken("?.", lexer.OptionalChain, 1, 11, 1, 13),
+				lexer.NewToken("[", lexer.OBracket, 1, 13, 1, 14),
+				lexer.NewToken("0", lexer.Number, 1, 14, 1, 15),
+				lexer.NewToken("]", lexer.CBracket, 1, 15, 1, 16),
+				lexer.NewToken("<EOF>", lexer.EOF, 1, 16, 1, 16),
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Spread of a Number",
+			input: "[...1, .5]",
+			want: []lexer.Token{
+				lexer.NewToken("[", lexer.OBracket, 1, 1, 1, 2),
+				lexer.NewToken(".", lexer.Dot, 1, 2, 1, 3),
+				lexer.NewToken(".", lexer.Dot, 1, 3, 1, 4),
+				lexer.NewToken(".", lexer.Dot, 1, 4, 1, 5),
+				lexer.NewToken("1", lexer.Number, 1, 5, 1, 6),
+				lexer.NewToken(",", lexer.Comma, 1, 6, 1, 7),
+				lexer.NewToken(".5", lexer.Number, 1, 8, 1, 10),
+				lexer.NewToken("]", lexer.CBracket, 1, 10, 1, 11),
+				lexer.NewToken("<EOF>", lexer.EOF, 1, 11, 1, 11),
+			},
+			wantErr: false,
+		},
 		{
 			name:    "Floating Point Number with Multiple Dots Error",
 			input:   "let b = 1.2.3",
@@ -442,3 +614,66 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+func TestMalformedNumbers(t *testing.T) {
+	tests := []struct {
+		input   string
+		col     int
+		message string
+	}{
+		{"x = 0x", 7, "Expected hexadecimal digits after 0x"},
+		{"x = 0b102", 9, "Invalid digit '2' in binary literal"},
+		{"x = 0o78", 8, "Invalid digit '8' in octal literal"},
+		{"x = 0xFG", 8, "Invalid character 'G' in numeric literal"},
+		{"x = 0x_1", 7, "Expected hexadecimal digits after 0x"},
+		{"x = 1__000", 6, "Numeric separators are only allowed between digits"},
+		{"x = 1_", 6, "Numeric separators are only allowed between digits"},
+		{"x = 1_.5", 6, "Numeric separators are only allowed between digits"},
+		{"x = 1.5_", 8, "Numeric separators are only allowed between digits"},
+		{"x = 1e", 7, "Expected exponent digits"},
+		{"x = 2.5e-", 10, "Expected exponent digits"},
+		{"x = 1e_5", 7, "Expected exponent digits"},
+		{"x = 123abc", 8, "Invalid character 'a' in numeric literal"},
+		{"x = 1e5x", 8, "Invalid character 'x' in numeric literal"},
+	}
+
+	for _, test := range tests {
+		_, err := lexer.Tokenize(test.input)
+		if err == nil {
+			t.Errorf("input=%q: expected an error", test.input)
+			continue
+		}
+		if err.Pos.Line != 1 || err.Pos.Col != test.col || err.Message != test.message {
+			t.Errorf("input=%q: expected %q at L1C%d, got %q at L%dC%d", test.input, test.message, test.col, err.Message, err.Pos.Line, err.Pos.Col)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		literal  string
+		expected float64
+	}{
+		{"42", 42},
+		{"2.5", 2.5},
+		{".5", 0.5},
+		{"0755", 755},
+		{"0xFF", 255},
+		{"0XfF", 255},
+		{"0b1010", 10},
+		{"0o755", 493},
+		{"1e9", 1e9},
+		{"2.5e-3", 0.0025},
+		{"1_000_000", 1000000},
+		{"0xFF_FF", 65535},
+		{"0x1FFFFFFFFFFFFFFFF", 36893488147419103232},
+		{"1e400", math.Inf(1)},
+	}
+
+	for _, test := range tests {
+		got, err := lexer.ParseNumber(test.literal)
+		if err != nil || got != test.expected {
+			t.Errorf("ParseNumber(%q) = %v, %v; expected %v", test.literal, got, err, test.expected)
+		}
+	}
+}
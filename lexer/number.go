@@ -0,0 +1,142 @@
+package lexer
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func isDecimalDigit(r rune) bool { return r >= '0' && r <= '9' }
+func isBinaryDigit(r rune) bool  { return r == '0' || r == '1' }
+func isOctalDigit(r rune) bool   { return r >= '0' && r <= '7' }
+func isHexDigit(r rune) bool {
+	return isDecimalDigit(r) || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
+
+// scanNumber scans the numeric literal starting at runes[start], a digit or a
+// dot followed by one, and returns the position just past it. line and col
+// locate runes[start], literals never span lines.
+//
+// A literal is either decimal, with an optional fraction and exponent (1,
+// 2.5, .5, 1e9, 2.5e-3), or an integer prefixed by 0x, 0b or 0o. Single
+// underscores may separate digits (1_000_000, 0xFF_FF).
+func scanNumber(runes []rune, start, line, col int) (int, *errors.LexerError) {
+	pos := start
+
+	errorAt := func(at int, format string, args ...interface{}) *errors.LexerError {
+		var char rune
+		if at < len(runes) {
+			char = runes[at]
+		}
+		return errors.NewLexerErrorf(errors.Position{Line: line, Col: col + at - start}, char, format, args...)
+	}
+
+	// digits consumes a run of at least one digit, separated by underscores
+	digits := func(isDigit func(rune) bool, expected string) *errors.LexerError {
+		if pos >= len(runes) || !isDigit(runes[pos]) {
+			return errorAt(pos, "Expected %s", expected)
+		}
+		for pos < len(runes) {
+			if isDigit(runes[pos]) {
+				pos++
+			} else if runes[pos] == '_' {
+				if pos+1 >= len(runes) || !isDigit(runes[pos+1]) {
+					return errorAt(pos, "Numeric separators are only allowed between digits")
+				}
+				pos++
+			} else {
+				break
+			}
+		}
+		return nil
+	}
+
+	// end rejects literals running into letters or digits, like 123abc or 0b12
+	end := func(kind string) (int, *errors.LexerError) {
+		if pos < len(runes) {
+			if r := runes[pos]; IsAlphaNumeric(r) || r == '_' || r == '$' {
+				if kind != "" && (unicode.IsDigit(r) || isHexDigit(r)) {
+					return 0, errorAt(pos, "Invalid digit %q in %s literal", r, kind)
+				}
+				return 0, errorAt(pos, "Invalid character %q in numeric literal", r)
+			}
+		}
+		return pos, nil
+	}
+
+	if runes[pos] == '0' && pos+1 < len(runes) {
+		var isDigit func(rune) bool
+		var kind string
+		switch runes[pos+1] {
+		case 'x', 'X':
+			isDigit, kind = isHexDigit, "hexadecimal"
+		case 'b', 'B':
+			isDigit, kind = isBinaryDigit, "binary"
+		case 'o', 'O':
+			isDigit, kind = isOctalDigit, "octal"
+		}
+
+		if isDigit != nil {
+			pos += 2
+			if err := digits(isDigit, kind+" digits after "+string(runes[start:pos])); err != nil {
+				return 0, err
+			}
+			return end(kind)
+		}
+	}
+
+	hasFraction := runes[pos] == '.'
+	if !hasFraction {
+		if err := digits(isDecimalDigit, "digit"); err != nil {
+			return 0, err
+		}
+		// A dot not followed by a digit is left out, as in 5.
+		hasFraction = pos+1 < len(runes) && runes[pos] == '.' && isDecimalDigit(runes[pos+1])
+	}
+	if hasFraction {
+		pos++ // .
+		if err := digits(isDecimalDigit, "digit"); err != nil {
+			return 0, err
+		}
+		if pos < len(runes) && runes[pos] == '.' {
+			return 0, &errors.LexerError{
+				Character: '.',
+				Pos:       errors.Position{Line: line, Col: col + pos - start},
+			}
+		}
+	}
+
+	if pos < len(runes) && (runes[pos] == 'e' || runes[pos] == 'E') {
+		pos++
+		if pos < len(runes) && (runes[pos] == '+' || runes[pos] == '-') {
+			pos++
+		}
+		if err := digits(isDecimalDigit, "exponent digits"); err != nil {
+			return 0, err
+		}
+	}
+
+	return end("")
+}
+
+// ParseNumber returns the value of a numeric literal produced by Tokenize.
+// Literals out of the float64 range are infinite.
+func ParseNumber(literal string) (float64, error) {
+	if len(literal) > 1 && literal[0] == '0' && strings.ContainsRune("xXbBoO", rune(literal[1])) {
+		integer, ok := new(big.Int).SetString(literal, 0)
+		if !ok {
+			return 0, &strconv.NumError{Func: "ParseNumber", Num: literal, Err: strconv.ErrSyntax}
+		}
+		value, _ := new(big.Float).SetInt(integer).Float64()
+		return value, nil
+	}
+
+	value, err := strconv.ParseFloat(strings.ReplaceAll(literal, "_", ""), 64)
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		return value, nil
+	}
+	return value, err
+}
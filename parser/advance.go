@@ -8,5 +8,6 @@ func (p *Parser) advance() *lexer.Token {
 	}
 	prev := p.tokens[0]
 	p.tokens = p.tokens[1:]
+	p.prev = prev
 	return &prev
 }
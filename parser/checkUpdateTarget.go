@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// checkUpdateTarget rejects operands of compound assignments and of `++`/`--`
+// that cannot be assigned to
+func (p *Parser) checkUpdateTarget(target ast.Expr, op *lexer.Token) *errors.SyntaxError {
+	if err := p.checkOptionalTarget(target, op); err != nil {
+		return err
+	}
+
+	switch target.(type) {
+	case *ast.Identifier, *ast.MemberExpr:
+		return nil
+	}
+	return errors.NewSyntaxErrorf(
+		errors.Position{Line: op.StartLine, Col: op.StartCol},
+		errors.Position{Line: op.EndLine, Col: op.EndCol},
+		"Invalid operand for `%s`, expected an identifier or a member expression", op.Literal,
+	)
+}
+
+// checkOptionalTarget rejects optional chains as assignment targets, there is
+// nothing to assign to when they short-circuit
+func (p *Parser) checkOptionalTarget(target ast.Expr, op *lexer.Token) *errors.SyntaxError {
+	if !ast.IsOptionalChain(target) {
+		return nil
+	}
+	return errors.NewSyntaxErrorf(
+		errors.Position{Line: op.StartLine, Col: op.StartCol},
+		errors.Position{Line: op.EndLine, Col: op.EndCol},
+		"Invalid operand for `%s`, an optional chain cannot be assigned to", op.Literal,
+	)
+}
@@ -9,7 +9,7 @@ import (
 func (p *Parser) parseArgsList() ([]ast.Expr, *errors.SyntaxError) {
 	var args = make([]ast.Expr, 1)
 
-	arg, err := p.parseAssignmentExpr()
+	arg, err := p.parseSpreadElement()
 	if err != nil {
 		return nil, err
 	}
@@ -18,7 +18,7 @@ func (p *Parser) parseArgsList() ([]ast.Expr, *errors.SyntaxError) {
 	for p.at().Type == lexer.Comma {
 		p.advance()
 
-		arg, err := p.parseAssignmentExpr()
+		arg, err := p.parseSpreadElement()
 		if err != nil {
 			return nil, err
 		}
@@ -12,7 +12,7 @@ func (p *Parser) parseArrayLiteral() (ast.Expr, *errors.SyntaxError) {
 	elements := []ast.Expr{}
 
 	for !p.isEOF() && p.at().Type != lexer.CBracket {
-		element, err := p.parseExpr()
+		element, err := p.parseSpreadElement()
 		if err != nil {
 			return nil, err
 		}
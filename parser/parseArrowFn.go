@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// isArrowFn reports whether the tokens ahead start an arrow function, either
+// `name =>` or a parameter list followed by `=>`. A parenthesized expression
+// is never followed by `=>`, so looking past the matching paren is enough
+func (p *Parser) isArrowFn() bool {
+	switch p.at().Type {
+	case lexer.Identifier:
+		return len(p.tokens) > 1 && p.tokens[1].Type == lexer.Arrow
+
+	case lexer.OParen:
+		depth := 0
+		for i, token := range p.tokens {
+			switch token.Type {
+			case lexer.OParen:
+				depth++
+			case lexer.CParen:
+				depth--
+				if depth == 0 {
+					return i+1 < len(p.tokens) && p.tokens[i+1].Type == lexer.Arrow
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// parseArrowFn parses `(params) => body` or `name => body` into an anonymous
+// function. A body that is not a block returns the value of its expression
+func (p *Parser) parseArrowFn() (*ast.FnDeclaration, *errors.SyntaxError) {
+	start := p.at()
+
+	var signature ast.Signature
+	if start.Type == lexer.Identifier {
+		signature = ast.Signature{
+			Params:   []string{p.advance().Literal},
+			Defaults: []ast.Expr{nil},
+			Patterns: []ast.DestructurePattern{nil},
+		}
+	} else {
+		var err *errors.SyntaxError
+		if signature, err = p.parseParams(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.expect(lexer.Arrow); err != nil {
+		return nil, err
+	}
+
+	body := []ast.Stmt{}
+
+	// loops outside of the function can't be targeted from its body
+	labels := p.labels
+	p.labels = nil
+	if p.at().Type == lexer.OBrace {
+		p.advance() // {
+		for !p.isEOF() && p.at().Type != lexer.CBrace {
+			stmt, err := p.parseStmt()
+			if err != nil {
+				return nil, err
+			}
+			body = append(body, stmt)
+		}
+
+		if _, err := p.expect(lexer.CBrace); err != nil {
+			return nil, err
+		}
+	} else {
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, &ast.ReturnStmt{
+			Value:          value,
+			SourceMetadata: value.GetSourceMetadata(),
+		})
+	}
+	p.labels = labels
+
+	return &ast.FnDeclaration{
+		Signature: signature,
+		Name:      "",
+		Body:      body,
+		Anonymous: true,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     p.prev.EndLine,
+			EndColumn:   p.prev.EndCol,
+		},
+	}, nil
+}
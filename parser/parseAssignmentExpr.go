@@ -8,13 +8,38 @@ import (
 
 func (p *Parser) parseAssignmentExpr() (ast.Expr, *errors.SyntaxError) {
 	start := p.at()
-	lhs, err := p.parseLogicalExpr()
+	lhs, err := p.parseConditionalExpr()
 	if err != nil {
 		return nil, err
 	}
 
 	if p.at().Type == lexer.Equals {
-		p.advance()
+		tok := p.advance()
+		if err := p.checkOptionalTarget(lhs, tok); err != nil {
+			return nil, err
+		}
+		value, err := p.parseAssignmentExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &ast.VarAssignmentExpr{
+			Value:    value,
+			Assignee: lhs,
+			SourceMetadata: ast.SourceMetadata{
+				Filename:    p.filename,
+				StartLine:   start.StartLine,
+				StartColumn: start.StartCol,
+				EndLine:     p.at().EndLine,
+				EndColumn:   p.at().EndCol,
+			},
+		}, nil
+	}
+
+	if p.at().Type == lexer.AssignOperator {
+		tok := p.advance()
+		if err := p.checkUpdateTarget(lhs, tok); err != nil {
+			return nil, err
+		}
 		value, err := p.parseAssignmentExpr()
 		if err != nil {
 			return nil, err
@@ -22,6 +47,7 @@ func (p *Parser) parseAssignmentExpr() (ast.Expr, *errors.SyntaxError) {
 		return &ast.VarAssignmentExpr{
 			Value:    value,
 			Assignee: lhs,
+			Operator: ast.AssignmentOperator(tok.Literal),
 			SourceMetadata: ast.SourceMetadata{
 				Filename:    p.filename,
 				StartLine:   start.StartLine,
@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// parseBinaryExpr parses a left-associative chain of operands parsed by next,
+// joined by any of operators
+func (p *Parser) parseBinaryExpr(operators []ast.BinaryOperator, next func() (ast.Expr, *errors.SyntaxError)) (ast.Expr, *errors.SyntaxError) {
+	start := p.at()
+	lhs, err := next()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.at().Type == lexer.BinOperator && slices.Contains(operators, ast.BinaryOperator(p.at().Literal)) {
+		operator := ast.BinaryOperator(p.advance().Literal)
+		rhs, err := next()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &ast.BinaryExpr{
+			Operator: operator,
+			LHS:      lhs,
+			RHS:      rhs,
+			SourceMetadata: ast.SourceMetadata{
+				Filename:    p.filename,
+				StartLine:   start.StartLine,
+				StartColumn: start.StartCol,
+				EndLine:     p.at().EndLine,
+				EndColumn:   p.at().EndCol,
+			},
+		}
+	}
+
+	return lhs, nil
+}
@@ -0,0 +1,22 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+// Bitwise operators bind looser than comparisons, like in JavaScript, so
+// `a & 1 == 0` is `a & (1 == 0)` and testing a bit takes parentheses:
+// `(a & 1) == 0`. From loosest to tightest: |, ^, &
+
+func (p *Parser) parseBitwiseOrExpr() (ast.Expr, *errors.SyntaxError) {
+	return p.parseBinaryExpr([]ast.BinaryOperator{ast.BitwiseOR}, p.parseBitwiseXorExpr)
+}
+
+func (p *Parser) parseBitwiseXorExpr() (ast.Expr, *errors.SyntaxError) {
+	return p.parseBinaryExpr([]ast.BinaryOperator{ast.BitwiseXOR}, p.parseBitwiseAndExpr)
+}
+
+func (p *Parser) parseBitwiseAndExpr() (ast.Expr, *errors.SyntaxError) {
+	return p.parseBinaryExpr([]ast.BinaryOperator{ast.BitwiseAND}, p.parseEqualityExpr)
+}
@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// isBlockStmt reports whether the `{` starting a statement opens a block
+// rather than an object literal. It opens an object literal when followed by
+// `}`, by a spread `...`, by an identifier followed by `,` or `}`, or by a key
+// followed by `:` (unless a loop follows, making the key a loop label).
+// Anything else opens a block
+func (p *Parser) isBlockStmt() bool {
+	if len(p.tokens) < 3 {
+		return false
+	}
+
+	first, second := p.tokens[1], p.tokens[2]
+	switch first.Type {
+	case lexer.CBrace, lexer.Dot:
+		return false
+	case lexer.Identifier:
+		switch second.Type {
+		case lexer.Comma, lexer.CBrace:
+			return false
+		case lexer.Colon:
+			if len(p.tokens) > 3 {
+				switch p.tokens[3].Type {
+				case lexer.WhileLoop, lexer.For, lexer.Do:
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	_, isKeyword := lexer.REVERSE_KEYWORDS[first.Type]
+	return !isKeyword || second.Type != lexer.Colon
+}
+
+func (p *Parser) parseBlockStmt() (*ast.BlockStmt, *errors.SyntaxError) {
+	start := p.advance() // {
+
+	body := []ast.Stmt{}
+	for !p.isEOF() && p.at().Type != lexer.CBrace {
+		stmt, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, stmt)
+	}
+
+	if _, err := p.expect(lexer.CBrace); err != nil {
+		return nil, err
+	}
+
+	return &ast.BlockStmt{
+		Body: body,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     p.at().EndLine,
+			EndColumn:   p.at().EndCol,
+		},
+	}, nil
+}
@@ -7,8 +7,13 @@ import (
 
 func (p *Parser) parseBreakStmt() (ast.Expr, *errors.SyntaxError) {
 	start := p.advance() // break
+	label, err := p.parseJumpLabel()
+	if err != nil {
+		return nil, err
+	}
 
 	return &ast.BreakStmt{
+		Label: label,
 		SourceMetadata: ast.SourceMetadata{
 			Filename:    p.filename,
 			StartLine:   start.StartLine,
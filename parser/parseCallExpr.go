@@ -8,14 +8,20 @@ import (
 
 func (p *Parser) parseCallExpr(callee ast.Expr) (*ast.CallExpr, *errors.SyntaxError) {
 	start := p.at()
+	optional := start.Type == lexer.OptionalChain
+	if optional {
+		p.advance() // ?.
+	}
+
 	args, err := p.parseArgs()
 	if err != nil {
 		return nil, err
 	}
 
 	callExpr := &ast.CallExpr{
-		Callee: callee,
-		Args:   args,
+		Callee:   callee,
+		Args:     args,
+		Optional: optional,
 		SourceMetadata: ast.SourceMetadata{
 			Filename:    p.filename,
 			StartLine:   start.StartLine,
@@ -25,7 +31,7 @@ func (p *Parser) parseCallExpr(callee ast.Expr) (*ast.CallExpr, *errors.SyntaxEr
 		},
 	}
 
-	if p.at().Type == lexer.OParen {
+	if p.at().Type == lexer.OParen || (p.at().Type == lexer.OptionalChain && len(p.tokens) > 1 && p.tokens[1].Type == lexer.OParen) {
 		expr, err := p.parseCallExpr(callExpr)
 		if err != nil {
 			return nil, err
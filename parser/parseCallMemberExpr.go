@@ -12,7 +12,7 @@ func (p *Parser) parseCallMemberExpr() (ast.Expr, *errors.SyntaxError) {
 		return nil, err
 	}
 
-	if p.at().Type == lexer.OParen {
+	if p.at().Type == lexer.OParen || p.at().Type == lexer.OptionalChain {
 		parsedCallExpr, err := p.parseCallExpr(member)
 		if err != nil {
 			return nil, err
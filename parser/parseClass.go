@@ -15,24 +15,30 @@ func (p *Parser) parseClass() (*ast.Class, *errors.SyntaxError) {
 	}
 
 	name := ident.Literal
-	// TODO: Implement class inheritance
+
+	var parent ast.Expr
+	if p.at().Type == lexer.Extends {
+		p.advance() // extends
+		parent, err = p.parseMemberExpr()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	_, err = p.expect(lexer.OBrace)
 	if err != nil {
 		return nil, err
 	}
 
 	body := []ast.Stmt{}
-	constructor := &ast.ClassMethod{
-		Name: "constructor",
-		Body: []ast.Stmt{},
-	}
+	var constructor *ast.ClassMethod
 
 	for !p.isEOF() && p.at().Type != lexer.CBrace {
 		stmt, err := p.parseClassStmt()
 		if err != nil {
 			return nil, err
 		}
-		if stmt.GetType() == ast.ClassMethodNode && stmt.(*ast.ClassMethod).Name == "constructor" {
+		if stmt.GetType() == ast.ClassMethodNode && stmt.(*ast.ClassMethod).Name == "constructor" && stmt.(*ast.ClassMethod).Accessor == "" {
 			constructor = stmt.(*ast.ClassMethod)
 		} else {
 			body = append(body, stmt)
@@ -44,8 +50,18 @@ func (p *Parser) parseClass() (*ast.Class, *errors.SyntaxError) {
 		return nil, err
 	}
 
+	// Subclasses without an explicit constructor inherit the parent's,
+	// so only base classes get an empty default one
+	if constructor == nil && parent == nil {
+		constructor = &ast.ClassMethod{
+			Name: "constructor",
+			Body: []ast.Stmt{},
+		}
+	}
+
 	return &ast.Class{
 		Name:        name,
+		Parent:      parent,
 		Body:        body,
 		Constructor: constructor,
 		SourceMetadata: ast.SourceMetadata{
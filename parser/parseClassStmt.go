@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/dev-kas/virtlang-go/v4/ast"
 	"github.com/dev-kas/virtlang-go/v4/errors"
 	"github.com/dev-kas/virtlang-go/v4/lexer"
@@ -18,31 +20,57 @@ func (p *Parser) parseClassStmt() (ast.Stmt, *errors.SyntaxError) {
 		}
 	}
 
+	isStatic := p.at().Type == lexer.Static
+	if isStatic {
+		p.advance()
+	}
+
 	ident, err := p.expect(lexer.Identifier)
 	if err != nil {
 		return nil, err
 	}
 
+	if isStatic && ident.Literal == "constructor" {
+		return nil, &errors.SyntaxError{
+			Expected: "static member name other than constructor",
+			Got:      ident.Literal,
+			Start:    errors.Position{Line: ident.StartLine, Col: ident.StartCol},
+			End:      errors.Position{Line: ident.EndLine, Col: ident.EndCol},
+		}
+	}
+
+	// `get` and `set` only introduce accessors when a name follows them, so
+	// they remain valid member names
+	var accessor ast.AccessorKind
+	if (ident.Literal == "get" || ident.Literal == "set") && p.at().Type == lexer.Identifier {
+		if isStatic || isPrivate {
+			return nil, &errors.SyntaxError{
+				Expected: "public instance accessor",
+				Got:      ident.Literal,
+				Start:    errors.Position{Line: ident.StartLine, Col: ident.StartCol},
+				End:      errors.Position{Line: ident.EndLine, Col: ident.EndCol},
+			}
+		}
+		accessor = ast.AccessorKind(ident.Literal)
+		ident = p.advance()
+	}
+
 	name := ident.Literal
 
 	isFunc := p.at().Type == lexer.OParen
+	if accessor != "" && !isFunc {
+		_, err := p.expect(lexer.OParen)
+		return nil, err
+	}
 	if isFunc {
-		args, err := p.parseArgs()
+		paramsStart := p.at()
+		signature, err := p.parseParams()
 		if err != nil {
 			return nil, err
 		}
-		params := []string{}
 
-		for _, arg := range args {
-			if arg.GetType() != ast.IdentifierNode {
-				return nil, &errors.SyntaxError{
-					Expected: "Identifier",
-					Got:      arg.GetType().String(),
-					Start:    errors.Position{Line: p.at().StartLine, Col: p.at().StartCol},
-					End:      errors.Position{Line: p.at().EndLine, Col: p.at().EndCol},
-				}
-			}
-			params = append(params, arg.(*ast.Identifier).Symbol)
+		if err := checkAccessorParams(accessor, signature, paramsStart, p.at()); err != nil {
+			return nil, err
 		}
 
 		_, err = p.expect(lexer.OBrace)
@@ -50,6 +78,8 @@ func (p *Parser) parseClassStmt() (ast.Stmt, *errors.SyntaxError) {
 			return nil, err
 		}
 		body := []ast.Stmt{}
+		labels := p.labels
+		p.labels = nil
 		for !p.isEOF() && p.at().Type != lexer.CBrace {
 			stmt, err := p.parseStmt()
 			if err != nil {
@@ -57,15 +87,18 @@ func (p *Parser) parseClassStmt() (ast.Stmt, *errors.SyntaxError) {
 			}
 			body = append(body, stmt)
 		}
+		p.labels = labels
 		_, err = p.expect(lexer.CBrace)
 		if err != nil {
 			return nil, err
 		}
 		return &ast.ClassMethod{
-			Name:     name,
-			Body:     body,
-			Params:   params,
-			IsPublic: !isPrivate,
+			Signature: signature,
+			Name:      name,
+			Body:      body,
+			IsPublic:  !isPrivate,
+			IsStatic:  isStatic,
+			Accessor:  accessor,
 			SourceMetadata: ast.SourceMetadata{
 				Filename:    p.filename,
 				StartLine:   start.StartLine,
@@ -81,6 +114,7 @@ func (p *Parser) parseClassStmt() (ast.Stmt, *errors.SyntaxError) {
 				Name:     name,
 				Value:    nil,
 				IsPublic: !isPrivate,
+				IsStatic: isStatic,
 			}, nil
 		}
 		p.advance()
@@ -92,6 +126,7 @@ func (p *Parser) parseClassStmt() (ast.Stmt, *errors.SyntaxError) {
 			Name:     name,
 			Value:    value,
 			IsPublic: !isPrivate,
+			IsStatic: isStatic,
 			SourceMetadata: ast.SourceMetadata{
 				Filename:    p.filename,
 				StartLine:   start.StartLine,
@@ -102,3 +137,24 @@ func (p *Parser) parseClassStmt() (ast.Stmt, *errors.SyntaxError) {
 		}, nil
 	}
 }
+
+// checkAccessorParams makes sure getters take no parameter and setters take
+// exactly one, the assigned value. The error spans the parameter list.
+func checkAccessorParams(accessor ast.AccessorKind, signature ast.Signature, start, end lexer.Token) *errors.SyntaxError {
+	var expected string
+	switch {
+	case accessor == ast.Getter && (len(signature.Params) != 0 || signature.Rest != nil):
+		expected = "no parameters for a getter"
+	case accessor == ast.Setter && (len(signature.Params) != 1 || signature.Rest != nil):
+		expected = "exactly one parameter for a setter"
+	default:
+		return nil
+	}
+
+	return &errors.SyntaxError{
+		Expected: expected,
+		Got:      fmt.Sprintf("%d parameters", len(signature.Params)),
+		Start:    errors.Position{Line: start.StartLine, Col: start.StartCol},
+		End:      errors.Position{Line: end.StartLine, Col: end.StartCol},
+	}
+}
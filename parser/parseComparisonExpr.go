@@ -1,50 +1,42 @@
 package parser
 
 import (
+	"slices"
+
 	"github.com/dev-kas/virtlang-go/v4/ast"
 	"github.com/dev-kas/virtlang-go/v4/errors"
 	"github.com/dev-kas/virtlang-go/v4/lexer"
 )
 
-func (p *Parser) parseComparisonExpr() (ast.Expr, *errors.SyntaxError) {
+// Comparisons follow JavaScript: equality binds looser than the relational
+// operators, which bind looser than shifts, and both levels are
+// left-associative, so `a < b == c < d` compares the results of `<`
+
+func (p *Parser) parseEqualityExpr() (ast.Expr, *errors.SyntaxError) {
+	return p.parseCompareExpr([]ast.CompareOperator{ast.Equal, ast.NotEqual}, p.parseRelationalExpr)
+}
+
+func (p *Parser) parseRelationalExpr() (ast.Expr, *errors.SyntaxError) {
+	operators := []ast.CompareOperator{ast.LessThan, ast.GreaterThan, ast.LessThanEqual, ast.GreaterThanEqual, ast.InstanceOf, ast.In}
+	return p.parseCompareExpr(operators, p.parseObjectExpr)
+}
+
+// parseCompareExpr parses a left-associative chain of operands parsed by
+// next, joined by any of operators
+func (p *Parser) parseCompareExpr(operators []ast.CompareOperator, next func() (ast.Expr, *errors.SyntaxError)) (ast.Expr, *errors.SyntaxError) {
 	start := p.at()
-	lhs, err := p.parseObjectExpr()
+	lhs, err := next()
 	if err != nil {
 		return nil, err
 	}
 
-	if p.at().Type == lexer.ComOperator {
-		operatorToken := p.advance().Literal
-
-		var operator ast.CompareOperator
-		switch operatorToken {
-		case "<":
-			operator = ast.LessThan
-		case "==":
-			operator = ast.Equal
-		case ">":
-			operator = ast.GreaterThan
-		case "!=":
-			operator = ast.NotEqual
-		case "<=":
-			operator = ast.LessThanEqual
-		case ">=":
-			operator = ast.GreaterThanEqual
-		default:
-			return nil, &errors.SyntaxError{
-				Expected: "<, ==, >, !=, <=, >=",
-				Got:      operatorToken,
-				Start:    errors.Position{Line: p.at().StartLine, Col: p.at().StartCol},
-				End:      errors.Position{Line: p.at().EndLine, Col: p.at().EndCol},
-			}
-		}
-
-		rhs, err := p.parseObjectExpr()
+	for p.isCompareOperator() && slices.Contains(operators, ast.CompareOperator(p.at().Literal)) {
+		operator := ast.CompareOperator(p.advance().Literal)
+		rhs, err := next()
 		if err != nil {
 			return nil, err
 		}
-
-		return &ast.CompareExpr{
+		lhs = &ast.CompareExpr{
 			LHS:      lhs,
 			RHS:      rhs,
 			Operator: operator,
@@ -55,8 +47,16 @@ func (p *Parser) parseComparisonExpr() (ast.Expr, *errors.SyntaxError) {
 				EndLine:     p.at().EndLine,
 				EndColumn:   p.at().EndCol,
 			},
-		}, nil
+		}
 	}
 
 	return lhs, nil
 }
+
+func (p *Parser) isCompareOperator() bool {
+	switch p.at().Type {
+	case lexer.ComOperator, lexer.InstanceOf, lexer.In:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// parseConditionalExpr parses `cond ? a : b`, binding looser than the logical
+// operators and tighter than assignment. Both branches may hold assignments,
+// and nesting in the alternate is right-associative: a ? b : c ? d : e is
+// a ? b : (c ? d : e)
+func (p *Parser) parseConditionalExpr() (ast.Expr, *errors.SyntaxError) {
+	start := p.at()
+	condition, err := p.parseLogicalExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.at().Type != lexer.QuestionMark {
+		return condition, nil
+	}
+	p.advance() // ?
+
+	consequent, err := p.parseAssignmentExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(lexer.Colon); err != nil {
+		return nil, err
+	}
+
+	alternate, err := p.parseAssignmentExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ConditionalExpr{
+		Condition:  condition,
+		Consequent: consequent,
+		Alternate:  alternate,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     alternate.GetSourceMetadata().EndLine,
+			EndColumn:   alternate.GetSourceMetadata().EndColumn,
+		},
+	}, nil
+}
@@ -7,8 +7,13 @@ import (
 
 func (p *Parser) parseContinueStmt() (ast.Expr, *errors.SyntaxError) {
 	start := p.advance() // continue
+	label, err := p.parseJumpLabel()
+	if err != nil {
+		return nil, err
+	}
 
 	return &ast.ContinueStmt{
+		Label: label,
 		SourceMetadata: ast.SourceMetadata{
 			Filename:    p.filename,
 			StartLine:   start.StartLine,
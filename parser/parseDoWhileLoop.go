@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+func (p *Parser) parseDoWhileLoop() (*ast.DoWhileLoop, *errors.SyntaxError) {
+	start := p.advance() // do
+
+	if _, err := p.expect(lexer.OBrace); err != nil {
+		return nil, err
+	}
+	body := []ast.Stmt{}
+
+	for !p.isEOF() && p.at().Type != lexer.CBrace {
+		stmt, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, stmt)
+	}
+
+	if _, err := p.expect(lexer.CBrace); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(lexer.WhileLoop); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(lexer.OParen); err != nil {
+		return nil, err
+	}
+	condition, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(lexer.CParen); err != nil {
+		return nil, err
+	}
+
+	return &ast.DoWhileLoop{
+		Condition: condition,
+		Body:      body,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     p.at().EndLine,
+			EndColumn:   p.at().EndCol,
+		},
+	}, nil
+}
@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// parseExponentExpr parses `**`, which is right-associative: 2 ** 3 ** 2 is
+// 2 ** (3 ** 2). Its base cannot be a unary expression, see parseUnaryExpr
+func (p *Parser) parseExponentExpr() (ast.Expr, *errors.SyntaxError) {
+	start := p.at()
+	base, err := p.parseUpdateExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.at().Type != lexer.BinOperator || p.at().Literal != string(ast.Exponent) {
+		return base, nil
+	}
+	p.advance()
+
+	exponent, err := p.parseUnaryExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.BinaryExpr{
+		Operator: ast.Exponent,
+		LHS:      base,
+		RHS:      exponent,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     exponent.GetSourceMetadata().EndLine,
+			EndColumn:   exponent.GetSourceMetadata().EndColumn,
+		},
+	}, nil
+}
@@ -18,23 +18,10 @@ func (p *Parser) parseFnDecl() (*ast.FnDeclaration, *errors.SyntaxError) {
 		name = &token
 	}
 	isAnonymous := name.Type == lexer.OParen
-	args, err := p.parseArgs()
+	signature, err := p.parseParams()
 	if err != nil {
 		return nil, err
 	}
-	params := []string{}
-
-	for _, arg := range args {
-		if arg.GetType() != ast.IdentifierNode {
-			return nil, &errors.SyntaxError{
-				Expected: "Identifier",
-				Got:      arg.GetType().String(),
-				Start:    errors.Position{Line: p.at().StartLine, Col: p.at().StartCol},
-				End:      errors.Position{Line: p.at().EndLine, Col: p.at().EndCol},
-			}
-		}
-		params = append(params, arg.(*ast.Identifier).Symbol)
-	}
 
 	if _, err := p.expect(lexer.OBrace); err != nil {
 		return nil, err
@@ -42,6 +29,9 @@ func (p *Parser) parseFnDecl() (*ast.FnDeclaration, *errors.SyntaxError) {
 
 	body := []ast.Stmt{}
 
+	// loops outside of the function can't be targeted from its body
+	labels := p.labels
+	p.labels = nil
 	for !p.isEOF() && p.at().Type != lexer.CBrace {
 		stmt, err := p.parseStmt()
 		if err != nil {
@@ -49,6 +39,7 @@ func (p *Parser) parseFnDecl() (*ast.FnDeclaration, *errors.SyntaxError) {
 		}
 		body = append(body, stmt)
 	}
+	p.labels = labels
 
 	if _, err := p.expect(lexer.CBrace); err != nil {
 		return nil, err
@@ -61,7 +52,7 @@ func (p *Parser) parseFnDecl() (*ast.FnDeclaration, *errors.SyntaxError) {
 	}
 
 	return &ast.FnDeclaration{
-		Params:    params,
+		Signature: signature,
 		Name:      fname,
 		Body:      body,
 		Anonymous: isAnonymous,
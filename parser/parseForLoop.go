@@ -0,0 +1,169 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+func (p *Parser) parseForLoop() (ast.Stmt, *errors.SyntaxError) {
+	start := p.advance() // for
+
+	if _, err := p.expect(lexer.OParen); err != nil {
+		return nil, err
+	}
+
+	if p.isForEachHeader() {
+		return p.parseForEachLoop(start)
+	}
+
+	// for (init; condition; update), every part is optional
+	var init ast.Stmt
+	if p.at().Type != lexer.SemiColon {
+		if p.at().Type == lexer.Let || p.at().Type == lexer.Const {
+			decl, err := p.parseVarDecl()
+			if err != nil {
+				return nil, err
+			}
+			init = decl
+		} else {
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			init = expr
+		}
+	}
+	if _, err := p.expect(lexer.SemiColon); err != nil {
+		return nil, err
+	}
+
+	var condition ast.Expr
+	if p.at().Type != lexer.SemiColon {
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		condition = expr
+	}
+	if _, err := p.expect(lexer.SemiColon); err != nil {
+		return nil, err
+	}
+
+	var update ast.Expr
+	if p.at().Type != lexer.CParen {
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		update = expr
+	}
+	if _, err := p.expect(lexer.CParen); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(lexer.OBrace); err != nil {
+		return nil, err
+	}
+	body := []ast.Stmt{}
+
+	for !p.isEOF() && p.at().Type != lexer.CBrace {
+		stmt, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, stmt)
+	}
+
+	if _, err := p.expect(lexer.CBrace); err != nil {
+		return nil, err
+	}
+
+	return &ast.ForLoop{
+		Init:      init,
+		Condition: condition,
+		Update:    update,
+		Body:      body,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     p.at().EndLine,
+			EndColumn:   p.at().EndCol,
+		},
+	}, nil
+}
+
+// isForEachHeader reports whether the tokens after `for (` read
+// `let x in` or `let x of` (`of` is not reserved, so it is matched by literal)
+func (p *Parser) isForEachHeader() bool {
+	if len(p.tokens) < 3 {
+		return false
+	}
+
+	decl, ident, keyword := p.tokens[0], p.tokens[1], p.tokens[2]
+	if decl.Type != lexer.Let && decl.Type != lexer.Const {
+		return false
+	}
+	if ident.Type != lexer.Identifier {
+		return false
+	}
+	return keyword.Type == lexer.In || (keyword.Type == lexer.Identifier && keyword.Literal == "of")
+}
+
+func (p *Parser) parseForEachLoop(start *lexer.Token) (ast.Stmt, *errors.SyntaxError) {
+	isConstant := p.advance().Type == lexer.Const // let / const
+	ident := p.advance()
+	isIn := p.advance().Type == lexer.In // in / of
+
+	source, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(lexer.CParen); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(lexer.OBrace); err != nil {
+		return nil, err
+	}
+	body := []ast.Stmt{}
+
+	for !p.isEOF() && p.at().Type != lexer.CBrace {
+		stmt, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, stmt)
+	}
+
+	if _, err := p.expect(lexer.CBrace); err != nil {
+		return nil, err
+	}
+
+	metadata := ast.SourceMetadata{
+		Filename:    p.filename,
+		StartLine:   start.StartLine,
+		StartColumn: start.StartCol,
+		EndLine:     p.at().EndLine,
+		EndColumn:   p.at().EndCol,
+	}
+
+	if isIn {
+		return &ast.ForInLoop{
+			Constant:       isConstant,
+			Identifier:     ident.Literal,
+			Object:         source,
+			Body:           body,
+			SourceMetadata: metadata,
+		}, nil
+	}
+
+	return &ast.ForOfLoop{
+		Constant:       isConstant,
+		Identifier:     ident.Literal,
+		Iterable:       source,
+		Body:           body,
+		SourceMetadata: metadata,
+	}, nil
+}
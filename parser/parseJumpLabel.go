@@ -0,0 +1,28 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// parseJumpLabel parses the optional label after `break` or `continue`, which
+// must be on the same line and name an enclosing loop
+func (p *Parser) parseJumpLabel() (string, *errors.SyntaxError) {
+	tok := p.at()
+	if tok.Type != lexer.Identifier || tok.StartLine != p.prev.EndLine {
+		return "", nil
+	}
+
+	if !slices.Contains(p.labels, tok.Literal) {
+		return "", &errors.SyntaxError{
+			Expected: "label of an enclosing loop",
+			Got:      tok.Literal,
+			Start:    errors.Position{Line: tok.StartLine, Col: tok.StartCol},
+			End:      errors.Position{Line: tok.EndLine, Col: tok.EndCol},
+		}
+	}
+	p.advance() // label
+	return tok.Literal, nil
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// parseLabeledLoop parses `label: loop`, making the loop a target of `break
+// label` and `continue label` in its body
+func (p *Parser) parseLabeledLoop() (ast.Stmt, *errors.SyntaxError) {
+	label := p.advance() // label
+	p.advance()          // :
+
+	if slices.Contains(p.labels, label.Literal) {
+		return nil, &errors.SyntaxError{
+			Expected: "label not used by an enclosing loop",
+			Got:      label.Literal,
+			Start:    errors.Position{Line: label.StartLine, Col: label.StartCol},
+			End:      errors.Position{Line: label.EndLine, Col: label.EndCol},
+		}
+	}
+
+	switch p.at().Type {
+	case lexer.WhileLoop, lexer.For, lexer.Do:
+	default:
+		return nil, &errors.SyntaxError{
+			Expected: "loop after label",
+			Got:      lexer.Stringify(p.at().Type),
+			Start:    errors.Position{Line: p.at().StartLine, Col: p.at().StartCol},
+			End:      errors.Position{Line: p.at().EndLine, Col: p.at().EndCol},
+		}
+	}
+
+	labels := p.labels
+	p.labels = append(labels, label.Literal)
+	loop, err := p.parseStmt()
+	p.labels = labels
+	if err != nil {
+		return nil, err
+	}
+
+	switch l := loop.(type) {
+	case *ast.WhileLoop:
+		l.Label = label.Literal
+	case *ast.DoWhileLoop:
+		l.Label = label.Literal
+	case *ast.ForLoop:
+		l.Label = label.Literal
+	case *ast.ForInLoop:
+		l.Label = label.Literal
+	case *ast.ForOfLoop:
+		l.Label = label.Literal
+	}
+	return loop, nil
+}
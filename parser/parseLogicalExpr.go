@@ -8,7 +8,7 @@ import (
 
 func (p *Parser) parseLogicalExpr() (ast.Expr, *errors.SyntaxError) {
 	start := p.at()
-	lhs, err := p.parseComparisonExpr()
+	lhs, err := p.parseBitwiseOrExpr()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// parseMatchExpr parses `match (subject) { case ... => body }`. A case lists
+// either values or a single destructuring pattern, optionally followed by an
+// `if` guard, and cases may be separated by commas
+func (p *Parser) parseMatchExpr() (*ast.MatchExpr, *errors.SyntaxError) {
+	start := p.advance() // match
+
+	if _, err := p.expect(lexer.OParen); err != nil {
+		return nil, err
+	}
+	subject, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(lexer.CParen); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(lexer.OBrace); err != nil {
+		return nil, err
+	}
+
+	cases := []ast.MatchCase{}
+	hasDefault := false
+	for !p.isEOF() && p.at().Type != lexer.CBrace {
+		caseStart := p.at()
+		matchCase := ast.MatchCase{}
+
+		if p.at().Type == lexer.Case && len(p.tokens) > 1 && (p.tokens[1].Type == lexer.OBrace || p.tokens[1].Type == lexer.OBracket) {
+			p.advance() // case
+			if matchCase.Pattern, err = p.parseDestructurePattern(); err != nil {
+				return nil, err
+			}
+		} else if matchCase.Values, err = p.parseCaseLabel(&hasDefault); err != nil {
+			return nil, err
+		}
+
+		if p.at().Type == lexer.If {
+			p.advance() // if
+			if matchCase.Guard, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := p.expect(lexer.Arrow); err != nil {
+			return nil, err
+		}
+		if matchCase.Body, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+
+		matchCase.SourceMetadata = ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   caseStart.StartLine,
+			StartColumn: caseStart.StartCol,
+			EndLine:     p.at().EndLine,
+			EndColumn:   p.at().EndCol,
+		}
+		cases = append(cases, matchCase)
+
+		if p.at().Type == lexer.Comma {
+			p.advance() // ,
+		}
+	}
+
+	if _, err := p.expect(lexer.CBrace); err != nil {
+		return nil, err
+	}
+
+	return &ast.MatchExpr{
+		Subject: subject,
+		Cases:   cases,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     p.at().EndLine,
+			EndColumn:   p.at().EndCol,
+		},
+	}, nil
+}
@@ -13,14 +13,31 @@ func (p *Parser) parseMemberExpr() (ast.Expr, *errors.SyntaxError) {
 		return nil, err
 	}
 
-	for p.at().Type == lexer.Dot || p.at().Type == lexer.OBracket {
-		operator := p.advance()
+	for {
 		var property ast.Expr
-		var computed bool
+		var computed, optional bool
 
-		if operator.Type == lexer.Dot {
-			computed = false
+		switch p.at().Type {
+		case lexer.OptionalChain:
+			if len(p.tokens) > 1 && p.tokens[1].Type == lexer.OParen {
+				return obj, nil // `?.(args)`, an optional call
+			}
+			p.advance()
+			optional = true
+			if p.at().Type == lexer.OBracket {
+				p.advance()
+				computed = true
+			}
+		case lexer.Dot:
+			p.advance()
+		case lexer.OBracket:
+			p.advance()
+			computed = true
+		default:
+			return obj, nil
+		}
 
+		if !computed {
 			var key *lexer.Token
 			var err *errors.SyntaxError
 			if _, ok := lexer.REVERSE_KEYWORDS[p.at().Type]; ok {
@@ -43,7 +60,6 @@ func (p *Parser) parseMemberExpr() (ast.Expr, *errors.SyntaxError) {
 				},
 			}
 		} else {
-			computed = true
 			property, err = p.parseExpr()
 			if err != nil {
 				return nil, err
@@ -59,6 +75,7 @@ func (p *Parser) parseMemberExpr() (ast.Expr, *errors.SyntaxError) {
 			Object:   obj,
 			Value:    property,
 			Computed: computed,
+			Optional: optional,
 			SourceMetadata: ast.SourceMetadata{
 				Filename:    p.filename,
 				StartLine:   start.StartLine,
@@ -68,6 +85,4 @@ func (p *Parser) parseMemberExpr() (ast.Expr, *errors.SyntaxError) {
 			},
 		}
 	}
-
-	return obj, nil
 }
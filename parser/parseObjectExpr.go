@@ -9,7 +9,7 @@ import (
 func (p *Parser) parseObjectExpr() (ast.Expr, *errors.SyntaxError) {
 	start := p.at()
 	if start.Type != lexer.OBrace {
-		return p.parseAdditiveExpr()
+		return p.parseShiftExpr()
 	}
 
 	p.advance() // {
@@ -17,6 +17,26 @@ func (p *Parser) parseObjectExpr() (ast.Expr, *errors.SyntaxError) {
 	properties := []ast.Property{}
 
 	for !p.isEOF() && p.at().Type != lexer.CBrace {
+		if p.at().Type == lexer.Dot { // { ...value }
+			spread, err := p.parseSpreadElement()
+			if err != nil {
+				return nil, err
+			}
+
+			properties = append(properties, ast.Property{
+				Key:            "",
+				Value:          spread,
+				SourceMetadata: spread.GetSourceMetadata(),
+			})
+
+			if p.at().Type != lexer.CBrace {
+				if _, err := p.expect(lexer.Comma); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
 		var key *lexer.Token
 		var err *errors.SyntaxError
 		if _, ok := lexer.REVERSE_KEYWORDS[p.at().Type]; ok {
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// parseParams parses the parameter list of a function or a class method.
+// Parameters are names or destructuring patterns, each optionally followed by
+// a default value, and the last one may be a rest parameter `...name`
+func (p *Parser) parseParams() (ast.Signature, *errors.SyntaxError) {
+	signature := ast.Signature{
+		Params:   []string{},
+		Defaults: []ast.Expr{},
+		Patterns: []ast.DestructurePattern{},
+	}
+
+	if _, err := p.expect(lexer.OParen); err != nil {
+		return signature, err
+	}
+
+	for !p.isEOF() && p.at().Type != lexer.CParen {
+		if p.at().Type == lexer.Dot {
+			for i := 0; i < 3; i++ {
+				if _, err := p.expect(lexer.Dot); err != nil {
+					return signature, err
+				}
+			}
+
+			rest, err := p.expect(lexer.Identifier)
+			if err != nil {
+				return signature, err
+			}
+			signature.Rest = &rest.Literal
+			break // the rest parameter is the last one
+		}
+
+		name := ""
+		var pattern ast.DestructurePattern
+		if at := p.at().Type; at == lexer.OBrace || at == lexer.OBracket {
+			var err *errors.SyntaxError
+			if pattern, err = p.parseDestructurePattern(); err != nil {
+				return signature, err
+			}
+		} else {
+			ident, err := p.expect(lexer.Identifier)
+			if err != nil {
+				return signature, err
+			}
+			name = ident.Literal
+		}
+
+		var defaultValue ast.Expr
+		if p.at().Type == lexer.Equals {
+			p.advance()
+
+			var err *errors.SyntaxError
+			if defaultValue, err = p.parseAssignmentExpr(); err != nil {
+				return signature, err
+			}
+		}
+
+		signature.Params = append(signature.Params, name)
+		signature.Defaults = append(signature.Defaults, defaultValue)
+		signature.Patterns = append(signature.Patterns, pattern)
+
+		if p.at().Type != lexer.CParen {
+			if _, err := p.expect(lexer.Comma); err != nil {
+				return signature, err
+			}
+		}
+	}
+
+	if _, err := p.expect(lexer.CParen); err != nil {
+		return signature, err
+	}
+	return signature, nil
+}
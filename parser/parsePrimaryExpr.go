@@ -1,8 +1,6 @@
 package parser
 
 import (
-	"strconv"
-
 	"github.com/dev-kas/virtlang-go/v4/ast"
 	"github.com/dev-kas/virtlang-go/v4/errors"
 	"github.com/dev-kas/virtlang-go/v4/lexer"
@@ -13,6 +11,10 @@ func (p *Parser) parsePrimaryExpr() (ast.Expr, *errors.SyntaxError) {
 	tk := start.Type
 	var value interface{}
 
+	if p.isArrowFn() {
+		return p.parseArrowFn()
+	}
+
 	switch tk {
 	case lexer.Identifier:
 		return &ast.Identifier{
@@ -26,9 +28,21 @@ func (p *Parser) parsePrimaryExpr() (ast.Expr, *errors.SyntaxError) {
 			},
 		}, nil
 
+	case lexer.Super:
+		return &ast.Identifier{
+			Symbol: p.advance().Literal,
+			SourceMetadata: ast.SourceMetadata{
+				Filename:    p.filename,
+				StartLine:   start.StartLine,
+				StartColumn: start.StartCol,
+				EndLine:     p.at().EndLine,
+				EndColumn:   p.at().EndCol,
+			},
+		}, nil
+
 	case lexer.Number:
 		value = p.advance().Literal
-		parsedValue, err := strconv.ParseFloat(value.(string), 64)
+		parsedValue, err := lexer.ParseNumber(value.(string))
 		if err != nil {
 			return nil, &errors.SyntaxError{
 				Expected: "Number",
@@ -55,11 +69,23 @@ func (p *Parser) parsePrimaryExpr() (ast.Expr, *errors.SyntaxError) {
 			return nil, err
 		}
 		p.expect(lexer.CParen)
+		switch e := expr.(type) {
+		case *ast.MemberExpr:
+			e.Parenthesized = true
+		case *ast.CallExpr:
+			e.Parenthesized = true
+		}
 		return expr, nil
 
 	case lexer.OBracket:
 		return p.parseArrayLiteral()
 
+	case lexer.Backtick:
+		return p.parseTemplateLiteral()
+
+	case lexer.Match:
+		return p.parseMatchExpr()
+
 	case lexer.String:
 		value = p.advance().Literal
 		return &ast.StringLiteral{
@@ -98,6 +124,9 @@ func (p *Parser) parsePrimaryExpr() (ast.Expr, *errors.SyntaxError) {
 	case lexer.Return:
 		return p.parseReturnStmt()
 
+	case lexer.Throw:
+		return p.parseThrowStmt()
+
 	case lexer.Break:
 		return p.parseBreakStmt()
 
@@ -0,0 +1,10 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (p *Parser) parseShiftExpr() (ast.Expr, *errors.SyntaxError) {
+	return p.parseBinaryExpr([]ast.BinaryOperator{ast.LeftShift, ast.RightShift}, p.parseAdditiveExpr)
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// parseSpreadElement parses `...expr` where a spread is allowed, falling back
+// to a plain expression otherwise
+func (p *Parser) parseSpreadElement() (ast.Expr, *errors.SyntaxError) {
+	if p.at().Type != lexer.Dot {
+		return p.parseAssignmentExpr()
+	}
+
+	start := p.at()
+	for i := 0; i < 3; i++ {
+		if _, err := p.expect(lexer.Dot); err != nil {
+			return nil, err
+		}
+	}
+
+	argument, err := p.parseAssignmentExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.SpreadElement{
+		Argument: argument,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     p.prev.EndLine,
+			EndColumn:   p.prev.EndCol,
+		},
+	}, nil
+}
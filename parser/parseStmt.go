@@ -16,6 +16,22 @@ func (p *Parser) parseStmt() (ast.Stmt, *errors.SyntaxError) {
 		return p.parseIfStmt()
 	case lexer.Class:
 		return p.parseClass()
+	case lexer.For:
+		return p.parseForLoop()
+	case lexer.Do:
+		return p.parseDoWhileLoop()
+	case lexer.Switch:
+		return p.parseSwitchStmt()
+	case lexer.OBrace:
+		if p.isBlockStmt() {
+			return p.parseBlockStmt()
+		}
+		return p.parseExpr()
+	case lexer.Identifier:
+		if len(p.tokens) > 1 && p.tokens[1].Type == lexer.Colon {
+			return p.parseLabeledLoop()
+		}
+		return p.parseExpr()
 	default:
 		return p.parseExpr()
 	}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+func (p *Parser) parseSwitchStmt() (*ast.SwitchStmt, *errors.SyntaxError) {
+	start := p.advance() // switch
+
+	if _, err := p.expect(lexer.OParen); err != nil {
+		return nil, err
+	}
+	discriminant, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(lexer.CParen); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(lexer.OBrace); err != nil {
+		return nil, err
+	}
+
+	cases := []ast.SwitchCase{}
+	hasDefault := false
+	for !p.isEOF() && p.at().Type != lexer.CBrace {
+		caseStart := p.at()
+		values, err := p.parseCaseLabel(&hasDefault)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(lexer.Colon); err != nil {
+			return nil, err
+		}
+
+		body := []ast.Stmt{}
+		for !p.isEOF() && p.at().Type != lexer.Case && p.at().Type != lexer.Default && p.at().Type != lexer.CBrace {
+			stmt, err := p.parseStmt()
+			if err != nil {
+				return nil, err
+			}
+			body = append(body, stmt)
+		}
+
+		cases = append(cases, ast.SwitchCase{
+			Values: values,
+			Body:   body,
+			SourceMetadata: ast.SourceMetadata{
+				Filename:    p.filename,
+				StartLine:   caseStart.StartLine,
+				StartColumn: caseStart.StartCol,
+				EndLine:     p.at().EndLine,
+				EndColumn:   p.at().EndCol,
+			},
+		})
+	}
+
+	if _, err := p.expect(lexer.CBrace); err != nil {
+		return nil, err
+	}
+
+	return &ast.SwitchStmt{
+		Discriminant: discriminant,
+		Cases:        cases,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     p.at().EndLine,
+			EndColumn:   p.at().EndCol,
+		},
+	}, nil
+}
+
+// parseCaseLabel parses `case value, ...` or `default`, returning the values
+// listed. A second default of the same switch or match is an error
+func (p *Parser) parseCaseLabel(hasDefault *bool) ([]ast.Expr, *errors.SyntaxError) {
+	if p.at().Type == lexer.Default {
+		if *hasDefault {
+			return nil, &errors.SyntaxError{
+				Expected: "a single default case",
+				Got:      p.at().Literal,
+				Start:    errors.Position{Line: p.at().StartLine, Col: p.at().StartCol},
+				End:      errors.Position{Line: p.at().EndLine, Col: p.at().EndCol},
+			}
+		}
+		*hasDefault = true
+		p.advance() // default
+		return nil, nil
+	}
+
+	if _, err := p.expect(lexer.Case); err != nil {
+		return nil, err
+	}
+
+	values := []ast.Expr{}
+	for {
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		if p.at().Type != lexer.Comma {
+			return values, nil
+		}
+		p.advance() // ,
+	}
+}
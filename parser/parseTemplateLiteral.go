@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+func (p *Parser) parseTemplateLiteral() (ast.Expr, *errors.SyntaxError) {
+	start := p.advance() // `
+
+	quasis := []string{""}
+	exprs := []ast.Expr{}
+
+	for p.at().Type != lexer.Backtick {
+		switch p.at().Type {
+		case lexer.TemplateString:
+			quasis[len(quasis)-1] = p.advance().Literal
+
+		case lexer.Interpolation:
+			p.advance() // ${
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(lexer.CBrace); err != nil {
+				return nil, err
+			}
+			exprs = append(exprs, expr)
+			quasis = append(quasis, "")
+
+		default:
+			return nil, &errors.SyntaxError{
+				Expected: "Backtick",
+				Got:      lexer.Stringify(p.at().Type),
+				Start:    errors.Position{Line: p.at().StartLine, Col: p.at().StartCol},
+				End:      errors.Position{Line: p.at().EndLine, Col: p.at().EndCol},
+			}
+		}
+	}
+
+	end := p.advance() // `
+
+	return &ast.TemplateLiteral{
+		Quasis: quasis,
+		Exprs:  exprs,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     end.EndLine,
+			EndColumn:   end.EndCol,
+		},
+	}, nil
+}
@@ -0,0 +1,26 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+)
+
+func (p *Parser) parseThrowStmt() (ast.Expr, *errors.SyntaxError) {
+	start := p.advance() // throw
+
+	value, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ThrowStmt{
+		Value: value,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     p.at().EndLine,
+			EndColumn:   p.at().EndCol,
+		},
+	}, nil
+}
@@ -25,37 +25,68 @@ func (p *Parser) parseTryCatch() (ast.Expr, *errors.SyntaxError) {
 	if _, err := p.expect(lexer.CBrace); err != nil {
 		return nil, err
 	}
-	if _, err := p.expect(lexer.Catch); err != nil {
-		return nil, err
-	}
 
-	cVar, err := p.expect(lexer.Identifier)
-	if err != nil {
-		return nil, err
-	}
+	// catch is optional when a finally block follows
+	catchVar := ""
+	var catchBody []ast.Stmt
+	if p.at().Type != lexer.Finally {
+		if _, err := p.expect(lexer.Catch); err != nil {
+			return nil, err
+		}
 
-	if _, err := p.expect(lexer.OBrace); err != nil {
-		return nil, err
-	}
+		cVar, err := p.expect(lexer.Identifier)
+		if err != nil {
+			return nil, err
+		}
+		catchVar = cVar.Literal
 
-	catchBody := []ast.Stmt{}
+		if _, err := p.expect(lexer.OBrace); err != nil {
+			return nil, err
+		}
 
-	for !p.isEOF() && p.at().Type != lexer.CBrace {
-		stmt, err := p.parseStmt()
-		if err != nil {
+		catchBody = []ast.Stmt{}
+
+		for !p.isEOF() && p.at().Type != lexer.CBrace {
+			stmt, err := p.parseStmt()
+			if err != nil {
+				return nil, err
+			}
+			catchBody = append(catchBody, stmt)
+		}
+
+		if _, err := p.expect(lexer.CBrace); err != nil {
 			return nil, err
 		}
-		catchBody = append(catchBody, stmt)
 	}
 
-	if _, err := p.expect(lexer.CBrace); err != nil {
-		return nil, err
+	var finallyBody []ast.Stmt
+	if p.at().Type == lexer.Finally {
+		p.advance() // finally
+
+		if _, err := p.expect(lexer.OBrace); err != nil {
+			return nil, err
+		}
+
+		finallyBody = []ast.Stmt{}
+
+		for !p.isEOF() && p.at().Type != lexer.CBrace {
+			stmt, err := p.parseStmt()
+			if err != nil {
+				return nil, err
+			}
+			finallyBody = append(finallyBody, stmt)
+		}
+
+		if _, err := p.expect(lexer.CBrace); err != nil {
+			return nil, err
+		}
 	}
 
 	return &ast.TryCatchStmt{
 		Try:      tryBody,
-		CatchVar: cVar.Literal,
+		CatchVar: catchVar,
 		Catch:    catchBody,
+		Finally:  finallyBody,
 		SourceMetadata: ast.SourceMetadata{
 			Filename:    p.filename,
 			StartLine:   start.StartLine,
@@ -6,30 +6,74 @@ import (
 	"github.com/dev-kas/virtlang-go/v4/lexer"
 )
 
+// parseUnaryExpr parses the prefix operators, whose operand is another unary
+// expression or an update expression. Like in JavaScript a unary expression
+// cannot be the base of `**`, so `-2 ** 2` is rejected in favour of
+// `(-2) ** 2` or `-(2 ** 2)`
 func (p *Parser) parseUnaryExpr() (ast.Expr, *errors.SyntaxError) {
-	tok := p.at()
+	if !p.atUnaryOperator() {
+		return p.parseExponentExpr()
+	}
 
-	// Logical NOT
-	if tok.Type == lexer.LogicalOperator && tok.Literal == "!" {
-		p.advance()
-		expr, err := p.parseUnaryExpr()
-		if err != nil {
-			return nil, err
-		}
+	tok := p.advance()
+	operand, err := p.parseUnaryOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.at(); next.Type == lexer.BinOperator && next.Literal == string(ast.Exponent) {
+		return nil, errors.NewSyntaxErrorf(
+			errors.Position{Line: next.StartLine, Col: next.StartCol},
+			errors.Position{Line: next.EndLine, Col: next.EndCol},
+			"Unary operator `%s` used immediately before `**`, parenthesize the base or the power", tok.Literal,
+		)
+	}
+
+	meta := ast.SourceMetadata{
+		Filename:    p.filename,
+		StartLine:   tok.StartLine,
+		StartColumn: tok.StartCol,
+		EndLine:     operand.GetSourceMetadata().EndLine,
+		EndColumn:   operand.GetSourceMetadata().EndColumn,
+	}
 
+	// Logical NOT
+	if tok.Type == lexer.LogicalOperator {
 		return &ast.LogicalExpr{
-			Operator: ast.LogicalNOT,
-			LHS:      nil,
-			RHS:      expr,
-			SourceMetadata: ast.SourceMetadata{
-				Filename:    p.filename,
-				StartLine:   tok.StartLine,
-				StartColumn: tok.StartCol,
-				EndLine:     expr.GetSourceMetadata().EndLine,
-				EndColumn:   expr.GetSourceMetadata().EndColumn,
-			},
+			Operator:       ast.LogicalNOT,
+			LHS:            nil,
+			RHS:            operand,
+			SourceMetadata: meta,
 		}, nil
 	}
 
-	return p.parseCallMemberExpr()
+	// Arithmetic, bitwise and typeof unary operators
+	return &ast.UnaryExpr{
+		Operator:       ast.UnaryOperator(tok.Literal),
+		Operand:        operand,
+		SourceMetadata: meta,
+	}, nil
+}
+
+func (p *Parser) atUnaryOperator() bool {
+	tok := p.at()
+	switch tok.Type {
+	case lexer.LogicalOperator:
+		return tok.Literal == "!"
+	case lexer.BinOperator:
+		return tok.Literal == "-" || tok.Literal == "+" || tok.Literal == "~"
+	}
+	return tok.Type == lexer.TypeOf
+}
+
+// parseUnaryOperand parses the operand of a unary operator, which may be an
+// object literal (`typeof {}`) as object literals are not primary expressions
+func (p *Parser) parseUnaryOperand() (ast.Expr, *errors.SyntaxError) {
+	switch {
+	case p.at().Type == lexer.OBrace:
+		return p.parseObjectExpr()
+	case p.atUnaryOperator():
+		return p.parseUnaryExpr()
+	}
+	return p.parseUpdateExpr()
 }
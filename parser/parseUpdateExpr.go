@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/lexer"
+)
+
+// parseUpdateExpr parses a prefix or postfix `++` or `--`. A postfix operator
+// has to be on the same line as its operand, so that a `++x` on the next line
+// starts a new statement instead
+func (p *Parser) parseUpdateExpr() (ast.Expr, *errors.SyntaxError) {
+	start := p.at()
+	if start.Type == lexer.UpdateOperator {
+		p.advance()
+		operand, err := p.parseCallMemberExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkUpdateTarget(operand, &start); err != nil {
+			return nil, err
+		}
+
+		return &ast.UpdateExpr{
+			Operator: ast.UpdateOperator(start.Literal),
+			Operand:  operand,
+			Prefix:   true,
+			SourceMetadata: ast.SourceMetadata{
+				Filename:    p.filename,
+				StartLine:   start.StartLine,
+				StartColumn: start.StartCol,
+				EndLine:     operand.GetSourceMetadata().EndLine,
+				EndColumn:   operand.GetSourceMetadata().EndColumn,
+			},
+		}, nil
+	}
+
+	operand, err := p.parseCallMemberExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.at()
+	if tok.Type != lexer.UpdateOperator || tok.StartLine != p.prev.EndLine {
+		return operand, nil
+	}
+	p.advance()
+	if err := p.checkUpdateTarget(operand, &tok); err != nil {
+		return nil, err
+	}
+
+	return &ast.UpdateExpr{
+		Operator: ast.UpdateOperator(tok.Literal),
+		Operand:  operand,
+		Prefix:   false,
+		SourceMetadata: ast.SourceMetadata{
+			Filename:    p.filename,
+			StartLine:   start.StartLine,
+			StartColumn: start.StartCol,
+			EndLine:     tok.EndLine,
+			EndColumn:   tok.EndCol,
+		},
+	}, nil
+}
@@ -5,6 +5,8 @@ import "github.com/dev-kas/virtlang-go/v4/lexer"
 type Parser struct {
 	tokens   []lexer.Token
 	filename string
+	labels   []string    // Labels of the loops enclosing the current position, reset at function boundaries
+	prev     lexer.Token // The last token consumed
 }
 
 func New(filename string) *Parser {
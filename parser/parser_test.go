@@ -1,6 +1,9 @@
 package parser_test
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/dev-kas/virtlang-go/v4/ast"
@@ -103,7 +106,19 @@ func TestBinaryExpr(t *testing.T) {
 		"1 *",
 		"1 /",
 		"1 %",
-		"+ 1",
+		"1 **",
+		"1 &",
+		"1 <<",
+		"** 1",
+		"& 1",
+		"1 += 2",
+		"a + b -= 1",
+		"f() = 1 ++",
+		"++1",
+		"++a++",
+		"a ? b",
+		"a ? b :",
+		"a ? : b",
 		"* 1",
 		"/ 1",
 		"% 1",
@@ -911,3 +926,602 @@ func TestDestructurePatterns(t *testing.T) {
 	testhelpers.ExpectParseError(t, "let [a, ...rest, ...extra] = arr") // multiple rest
 	testhelpers.ExpectParseError(t, "let [a, b, , ...rest, c] = arr")   // rest not last even with skipped element
 }
+
+func TestClassExtends(t *testing.T) {
+	prog := testhelpers.MustParse(t, "class Dog extends Animal { public constructor(n) { super(n) } }")
+	if len(prog.Stmts) != 1 {
+		t.Fatalf("Expected 1 statement, got %d", len(prog.Stmts))
+	}
+
+	class, ok := prog.Stmts[0].(*ast.Class)
+	if !ok {
+		t.Fatalf("Expected Class, got %s", prog.Stmts[0].GetType())
+	}
+	parent, ok := class.Parent.(*ast.Identifier)
+	if !ok || parent.Symbol != "Animal" {
+		t.Fatalf("Expected parent to be identifier Animal, got %v", class.Parent)
+	}
+	if class.Constructor == nil || len(class.Constructor.Body) != 1 {
+		t.Fatalf("Expected constructor with 1 statement, got %v", class.Constructor)
+	}
+	call, ok := class.Constructor.Body[0].(*ast.CallExpr)
+	if !ok {
+		t.Fatalf("Expected super call, got %s", class.Constructor.Body[0].GetType())
+	}
+	if callee, ok := call.Callee.(*ast.Identifier); !ok || callee.Symbol != "super" {
+		t.Fatalf("Expected callee to be super, got %v", call.Callee)
+	}
+
+	// subclasses without a constructor inherit the parent's
+	prog = testhelpers.MustParse(t, "class Dog extends Animal {}")
+	if prog.Stmts[0].(*ast.Class).Constructor != nil {
+		t.Fatalf("Expected no constructor on subclass without one")
+	}
+	prog = testhelpers.MustParse(t, "class Animal {}")
+	if prog.Stmts[0].(*ast.Class).Constructor == nil {
+		t.Fatalf("Expected default constructor on base class")
+	}
+
+	testhelpers.ExpectParseError(t, "class Dog extends { }")
+	testhelpers.ExpectParseError(t, "class Dog extends Animal")
+}
+
+func TestForLoop(t *testing.T) {
+	prog := testhelpers.MustParse(t, "for (let i = 0; i < 10; i = i + 1) { i }")
+	loop, ok := prog.Stmts[0].(*ast.ForLoop)
+	if !ok {
+		t.Fatalf("Expected ForLoop, got %s", prog.Stmts[0].GetType())
+	}
+	if loop.Init == nil || loop.Init.GetType() != ast.VarDeclarationNode {
+		t.Fatalf("Expected init to be a VarDeclaration, got %v", loop.Init)
+	}
+	if loop.Condition == nil || loop.Condition.GetType() != ast.CompareExprNode {
+		t.Fatalf("Expected condition to be a CompareExpr, got %v", loop.Condition)
+	}
+	if loop.Update == nil || loop.Update.GetType() != ast.VarAssignmentExprNode {
+		t.Fatalf("Expected update to be a VarAssignmentExpr, got %v", loop.Update)
+	}
+	if len(loop.Body) != 1 {
+		t.Fatalf("Expected 1 statement in body, got %d", len(loop.Body))
+	}
+
+	prog = testhelpers.MustParse(t, "for (;;) {}")
+	loop = prog.Stmts[0].(*ast.ForLoop)
+	if loop.Init != nil || loop.Condition != nil || loop.Update != nil {
+		t.Fatalf("Expected empty loop header, got %+v", loop)
+	}
+
+	prog = testhelpers.MustParse(t, "for (const x of items) {}")
+	forOf, ok := prog.Stmts[0].(*ast.ForOfLoop)
+	if !ok {
+		t.Fatalf("Expected ForOfLoop, got %s", prog.Stmts[0].GetType())
+	}
+	if !forOf.Constant || forOf.Identifier != "x" || forOf.Iterable.(*ast.Identifier).Symbol != "items" {
+		t.Fatalf("Unexpected ForOfLoop: %+v", forOf)
+	}
+
+	prog = testhelpers.MustParse(t, "for (let k in obj) {}")
+	forIn, ok := prog.Stmts[0].(*ast.ForInLoop)
+	if !ok {
+		t.Fatalf("Expected ForInLoop, got %s", prog.Stmts[0].GetType())
+	}
+	if forIn.Constant || forIn.Identifier != "k" || forIn.Object.(*ast.Identifier).Symbol != "obj" {
+		t.Fatalf("Unexpected ForInLoop: %+v", forIn)
+	}
+
+	// `of` stays usable as an identifier
+	testhelpers.MustParse(t, "let of = 1\nfor (let i = of; i < 3; i = i + 1) {}")
+
+	testhelpers.ExpectParseError(t, "for (let i = 0; i < 3) {}")
+	testhelpers.ExpectParseError(t, "for let x of arr {}")
+	testhelpers.ExpectParseError(t, "for (let x of arr)")
+	testhelpers.ExpectParseError(t, "for (x of arr) {}")
+}
+
+func TestThrowStmt(t *testing.T) {
+	prog := testhelpers.MustParse(t, "throw { message: 'oops' }")
+	throw, ok := prog.Stmts[0].(*ast.ThrowStmt)
+	if !ok {
+		t.Fatalf("Expected ThrowStmt, got %s", prog.Stmts[0].GetType())
+	}
+	if throw.Value.GetType() != ast.ObjectLiteralNode {
+		t.Fatalf("Expected thrown value to be an ObjectLiteral, got %s", throw.Value.GetType())
+	}
+
+	testhelpers.ExpectParseError(t, "throw")
+}
+
+func TestTryFinally(t *testing.T) {
+	prog := testhelpers.MustParse(t, "try { a } catch e { b } finally { c\nd }")
+	stmt := prog.Stmts[0].(*ast.TryCatchStmt)
+	if stmt.CatchVar != "e" || len(stmt.Catch) != 1 || len(stmt.Finally) != 2 {
+		t.Fatalf("Unexpected try statement: %+v", stmt)
+	}
+
+	prog = testhelpers.MustParse(t, "try { a } finally { c }")
+	stmt = prog.Stmts[0].(*ast.TryCatchStmt)
+	if stmt.CatchVar != "" || stmt.Catch != nil || len(stmt.Finally) != 1 {
+		t.Fatalf("Unexpected try statement: %+v", stmt)
+	}
+
+	testhelpers.ExpectParseError(t, "try { a }")
+	testhelpers.ExpectParseError(t, "try { a } finally")
+	testhelpers.ExpectParseError(t, "try { a } finally { b } catch e { c }")
+}
+
+func TestTemplateLiteral(t *testing.T) {
+	tests := []struct {
+		input  string
+		quasis []string
+		exprs  int
+	}{
+		{"``", []string{""}, 0},
+		{"`plain`", []string{"plain"}, 0},
+		{"`${a}`", []string{"", ""}, 1},
+		{"`a${b}c${d + 1}`", []string{"a", "c", ""}, 2},
+		{"`${a}${b}`", []string{"", "", ""}, 2},
+		{"`x ${ {k: 1} } y`", []string{"x ", " y"}, 1},
+	}
+
+	for _, test := range tests {
+		prog := testhelpers.MustParse(t, test.input)
+		template, ok := prog.Stmts[0].(*ast.TemplateLiteral)
+		if !ok {
+			t.Fatalf("input=%q: expected TemplateLiteral, got %s", test.input, prog.Stmts[0].GetType())
+		}
+		if !reflect.DeepEqual(template.Quasis, test.quasis) || len(template.Exprs) != test.exprs {
+			t.Errorf("input=%q: expected quasis %q and %d expressions, got %q and %d", test.input, test.quasis, test.exprs, template.Quasis, len(template.Exprs))
+		}
+	}
+
+	testhelpers.ExpectParseError(t, "`${}`")
+	testhelpers.ExpectParseError(t, "`${a b}`")
+	testhelpers.ExpectParseError(t, "`${a`")
+}
+
+func TestSpreadAndRest(t *testing.T) {
+	prog := testhelpers.MustParse(t, "f(a, ...b)\n[...a, b]\nlet o = { ...a, b: 1 }")
+
+	call := prog.Stmts[0].(*ast.CallExpr)
+	if _, ok := call.Args[1].(*ast.SpreadElement); !ok || len(call.Args) != 2 {
+		t.Errorf("expected a spread as the second argument, got %#v", call.Args)
+	}
+
+	array := prog.Stmts[1].(*ast.ArrayLiteral)
+	if _, ok := array.Elements[0].(*ast.SpreadElement); !ok || len(array.Elements) != 2 {
+		t.Errorf("expected a spread as the first element, got %#v", array.Elements)
+	}
+
+	object := prog.Stmts[2].(*ast.VarDeclaration).Value.(*ast.ObjectLiteral)
+	if spread, ok := object.Properties[0].Value.(*ast.SpreadElement); !ok || object.Properties[0].Key != "" || spread.Argument.(*ast.Identifier).Symbol != "a" {
+		t.Errorf("expected a spread as the first property, got %#v", object.Properties)
+	}
+
+	fn := testhelpers.MustParse(t, "fn f(a, b, ...rest) {}").Stmts[0].(*ast.FnDeclaration)
+	if !reflect.DeepEqual(fn.Params, []string{"a", "b"}) || fn.Rest == nil || *fn.Rest != "rest" {
+		t.Errorf("expected params [a b] and rest parameter, got %v and %v", fn.Params, fn.Rest)
+	}
+
+	method := testhelpers.MustParse(t, "class C { public m(...args) {} }").Stmts[0].(*ast.Class).Body[0].(*ast.ClassMethod)
+	if len(method.Params) != 0 || method.Rest == nil || *method.Rest != "args" {
+		t.Errorf("expected only a rest parameter, got %v and %v", method.Params, method.Rest)
+	}
+
+	testhelpers.ExpectParseError(t, "fn f(...rest, a) {}")
+	testhelpers.ExpectParseError(t, "fn f(...[a]) {}")
+	testhelpers.ExpectParseError(t, "fn f(..rest) {}")
+	testhelpers.ExpectParseError(t, "f(...)")
+	testhelpers.ExpectParseError(t, "{ ...a b }")
+}
+
+func TestParamDefaultsAndPatterns(t *testing.T) {
+	fn := testhelpers.MustParse(t, "fn f(a, b = a + 1, { c, d = 2 }, [e] = [], ...rest) {}").Stmts[0].(*ast.FnDeclaration)
+
+	if !reflect.DeepEqual(fn.Params, []string{"a", "b", "", ""}) || fn.Rest == nil || *fn.Rest != "rest" {
+		t.Fatalf("expected params [a b  ] and rest parameter, got %q and %v", fn.Params, fn.Rest)
+	}
+	if fn.Default(0) != nil || fn.Pattern(0) != nil {
+		t.Errorf("expected a plain first parameter, got %#v and %#v", fn.Default(0), fn.Pattern(0))
+	}
+	if _, ok := fn.Default(1).(*ast.BinaryExpr); !ok || fn.Pattern(1) != nil {
+		t.Errorf("expected a default value for the second parameter, got %#v", fn.Default(1))
+	}
+	if pattern, ok := fn.Pattern(2).(*ast.DestructureObjectPattern); !ok || len(pattern.Properties) != 2 || fn.Default(2) != nil {
+		t.Errorf("expected an object pattern for the third parameter, got %#v", fn.Pattern(2))
+	}
+	if _, ok := fn.Pattern(3).(*ast.DestructureArrayPattern); !ok || fn.Default(3) == nil {
+		t.Errorf("expected an array pattern with a default value for the fourth parameter, got %#v", fn.Pattern(3))
+	}
+
+	method := testhelpers.MustParse(t, "class C { public m({ x } = {}, y = 1) {} }").Stmts[0].(*ast.Class).Body[0].(*ast.ClassMethod)
+	if len(method.Params) != 2 || method.Pattern(0) == nil || method.Default(0) == nil || method.Default(1) == nil {
+		t.Errorf("expected a destructured and a defaulted parameter, got %#v", method.Signature)
+	}
+
+	testhelpers.ExpectParseError(t, "fn f(a = ) {}")
+	testhelpers.ExpectParseError(t, "fn f(a b) {}")
+	testhelpers.ExpectParseError(t, "fn f(1) {}")
+	testhelpers.ExpectParseError(t, "fn f(...rest = []) {}")
+	testhelpers.ExpectParseError(t, "fn f({ a ) {}")
+}
+
+func TestArrowFn(t *testing.T) {
+	tests := []struct {
+		input  string
+		params []string
+		block  bool
+	}{
+		{"() => 1", []string{}, false},
+		{"x => x * 2", []string{"x"}, false},
+		{"(a, b) => a + b", []string{"a", "b"}, false},
+		{"(a, { b }, c = (1 + 2)) => { return a }", []string{"a", "", "c"}, true},
+		{"() => { }", []string{}, true},
+	}
+
+	for _, test := range tests {
+		prog := testhelpers.MustParse(t, test.input)
+		fn, ok := prog.Stmts[0].(*ast.FnDeclaration)
+		if !ok || !fn.Anonymous || fn.Name != "" {
+			t.Fatalf("input=%q: expected an anonymous FnDeclaration, got %#v", test.input, prog.Stmts[0])
+		}
+		if !reflect.DeepEqual(fn.Params, test.params) {
+			t.Errorf("input=%q: expected params %q, got %q", test.input, test.params, fn.Params)
+		}
+		if !test.block {
+			if len(fn.Body) != 1 || fn.Body[0].GetType() != ast.ReturnStmtNode {
+				t.Errorf("input=%q: expected an implicit return, got %#v", test.input, fn.Body)
+			}
+		}
+	}
+
+	// parenthesized expressions are not parameter lists
+	prog := testhelpers.MustParse(t, "(a + b) * (c)")
+	if prog.Stmts[0].GetType() != ast.BinaryExprNode {
+		t.Errorf("expected a BinaryExpr, got %s", prog.Stmts[0].GetType())
+	}
+
+	call := testhelpers.MustParse(t, "f((x) => x, (y))").Stmts[0].(*ast.CallExpr)
+	if call.Args[0].GetType() != ast.FnDeclarationNode || call.Args[1].GetType() != ast.IdentifierNode {
+		t.Errorf("expected an arrow function and an identifier, got %#v", call.Args)
+	}
+
+	testhelpers.ExpectParseError(t, "(a + 1) => a")
+	testhelpers.ExpectParseError(t, "(a) =>")
+	testhelpers.ExpectParseError(t, "(a) => { return a")
+}
+
+func TestOptionalChain(t *testing.T) {
+	member := testhelpers.MustParse(t, "a?.b.c").Stmts[0].(*ast.MemberExpr)
+	inner, ok := member.Object.(*ast.MemberExpr)
+	if !ok || member.Optional || !inner.Optional || inner.Computed || !ast.IsOptionalChain(member) {
+		t.Errorf("expected `a?.b` inside `.c`, got %#v", member)
+	}
+
+	computed := testhelpers.MustParse(t, "a?.[i]").Stmts[0].(*ast.MemberExpr)
+	if !computed.Optional || !computed.Computed {
+		t.Errorf("expected an optional computed member, got %#v", computed)
+	}
+
+	call := testhelpers.MustParse(t, "f?.(1)?.(2)").Stmts[0].(*ast.CallExpr)
+	if callee, ok := call.Callee.(*ast.CallExpr); !ok || !call.Optional || !callee.Optional || len(callee.Args) != 1 {
+		t.Errorf("expected two optional calls, got %#v", call)
+	}
+
+	method := testhelpers.MustParse(t, "a.b?.c(1)").Stmts[0].(*ast.CallExpr)
+	if method.Optional || !ast.IsOptionalChain(method) {
+		t.Errorf("expected a plain call ending an optional chain, got %#v", method)
+	}
+
+	if ast.IsOptionalChain(testhelpers.MustParse(t, "a.b(c?.d)").Stmts[0].(*ast.CallExpr)) {
+		t.Errorf("expected an optional argument not to make the call optional")
+	}
+
+	grouped := testhelpers.MustParse(t, "(a?.b).c").Stmts[0].(*ast.MemberExpr)
+	if inner, ok := grouped.Object.(*ast.MemberExpr); !ok || !inner.Parenthesized || grouped.Parenthesized || ast.IsOptionalChain(grouped) || !ast.IsOptionalChain(inner) {
+		t.Errorf("expected parentheses to end the optional chain, got %#v", grouped)
+	}
+	if groupedCall := testhelpers.MustParse(t, "(a?.f)()").Stmts[0].(*ast.CallExpr); ast.IsOptionalChain(groupedCall) {
+		t.Errorf("expected parentheses to end the optional chain, got %#v", groupedCall)
+	}
+	if !ast.IsOptionalChain(testhelpers.MustParse(t, "(a?.b)?.c.d").Stmts[0].(*ast.MemberExpr)) {
+		t.Errorf("expected an optional link after parentheses to start a chain")
+	}
+	testhelpers.MustParse(t, "(a?.b).c = 1")
+
+	testhelpers.ExpectParseError(t, "a?.b = 1")
+	testhelpers.ExpectParseError(t, "a?.[0] += 1")
+	testhelpers.ExpectParseError(t, "a?.b++")
+	testhelpers.ExpectParseError(t, "(a?.b) = 1")
+	testhelpers.ExpectParseError(t, "a?.")
+	testhelpers.ExpectParseError(t, "a?.(")
+}
+
+func TestSwitchStmt(t *testing.T) {
+	stmt := testhelpers.MustParse(t, "switch (x) { case 1, 2: a()\nb()\ndefault: c()\ncase 3: }").Stmts[0].(*ast.SwitchStmt)
+	if len(stmt.Cases) != 3 {
+		t.Fatalf("expected 3 cases, got %d", len(stmt.Cases))
+	}
+	if len(stmt.Cases[0].Values) != 2 || len(stmt.Cases[0].Body) != 2 {
+		t.Errorf("expected a case with 2 values and 2 statements, got %#v", stmt.Cases[0])
+	}
+	if len(stmt.Cases[1].Values) != 0 || len(stmt.Cases[1].Body) != 1 {
+		t.Errorf("expected the default case, got %#v", stmt.Cases[1])
+	}
+	if len(stmt.Cases[2].Values) != 1 || len(stmt.Cases[2].Body) != 0 {
+		t.Errorf("expected an empty case, got %#v", stmt.Cases[2])
+	}
+
+	testhelpers.ExpectParseError(t, "switch (x) { default: a()\ndefault: b() }")
+	testhelpers.ExpectParseError(t, "switch (x) { a() }")
+	testhelpers.ExpectParseError(t, "switch (x) { case: a() }")
+	testhelpers.ExpectParseError(t, "switch (x) { case 1 a() }")
+}
+
+func TestMatchExpr(t *testing.T) {
+	expr := testhelpers.MustParse(t, "match (x) { case 1, 2 => 'a', case { b } if b > 1 => b\ncase [c, ...d] => c\ndefault => nil }").Stmts[0].(*ast.MatchExpr)
+	if len(expr.Cases) != 4 {
+		t.Fatalf("expected 4 cases, got %d", len(expr.Cases))
+	}
+	if len(expr.Cases[0].Values) != 2 || expr.Cases[0].Pattern != nil {
+		t.Errorf("expected a case with 2 values, got %#v", expr.Cases[0])
+	}
+	if _, ok := expr.Cases[1].Pattern.(*ast.DestructureObjectPattern); !ok || expr.Cases[1].Guard == nil {
+		t.Errorf("expected a guarded object pattern, got %#v", expr.Cases[1])
+	}
+	if _, ok := expr.Cases[2].Pattern.(*ast.DestructureArrayPattern); !ok || expr.Cases[2].Guard != nil {
+		t.Errorf("expected an array pattern, got %#v", expr.Cases[2])
+	}
+	if expr.Cases[3].Values != nil || expr.Cases[3].Pattern != nil {
+		t.Errorf("expected the default case, got %#v", expr.Cases[3])
+	}
+
+	if _, ok := testhelpers.MustParse(t, "let y = match (x) { default => 1 }").Stmts[0].(*ast.VarDeclaration).Value.(*ast.MatchExpr); !ok {
+		t.Errorf("expected match to be an expression")
+	}
+
+	testhelpers.ExpectParseError(t, "match (x) { case 1: 2 }")
+	testhelpers.ExpectParseError(t, "match (x) { default => 1, default => 2 }")
+	testhelpers.ExpectParseError(t, "match (x) { case { a }, { b } => 1 }")
+}
+
+func TestLabeledLoops(t *testing.T) {
+	loop := testhelpers.MustParse(t, "outer: while (a) { inner: for (let i = 0; i < 1; i++) { break outer\ncontinue inner\nbreak\nouter } }").Stmts[0].(*ast.WhileLoop)
+	if loop.Label != "outer" {
+		t.Errorf("expected the label outer, got %q", loop.Label)
+	}
+	inner := loop.Body[0].(*ast.ForLoop)
+	if inner.Label != "inner" || len(inner.Body) != 4 {
+		t.Fatalf("expected a loop labeled inner with 4 statements, got %#v", inner)
+	}
+	if inner.Body[0].(*ast.BreakStmt).Label != "outer" || inner.Body[1].(*ast.ContinueStmt).Label != "inner" {
+		t.Errorf("expected labeled break and continue, got %#v", inner.Body)
+	}
+	if inner.Body[2].(*ast.BreakStmt).Label != "" {
+		t.Errorf("expected a label on the next line to be a statement of its own")
+	}
+
+	do := testhelpers.MustParse(t, "retry: do { x() } while (again)").Stmts[0].(*ast.DoWhileLoop)
+	if do.Label != "retry" || len(do.Body) != 1 || do.Condition == nil {
+		t.Errorf("expected a labeled do-while loop, got %#v", do)
+	}
+
+	each := testhelpers.MustParse(t, "items: for (const x of xs) { continue items }").Stmts[0].(*ast.ForOfLoop)
+	if each.Label != "items" {
+		t.Errorf("expected the label items, got %q", each.Label)
+	}
+
+	testhelpers.ExpectParseError(t, "while (a) { break outer }")
+	testhelpers.ExpectParseError(t, "outer: while (a) { fn f() { break outer } }")
+	testhelpers.ExpectParseError(t, "outer: while (a) { x => { continue outer } }")
+	testhelpers.ExpectParseError(t, "outer: while (a) { outer: while (b) {} }")
+	testhelpers.ExpectParseError(t, "outer: x + 1")
+	testhelpers.ExpectParseError(t, "do { x() } (a)")
+	testhelpers.ExpectParseError(t, "do x() while (a)")
+}
+
+func TestBlockStmt(t *testing.T) {
+	blocks := []string{
+		"{ let x = 1 }",
+		"{ x = 1 }",
+		"{ f()\ng() }",
+		"{ { x } }",
+		"{ loop: while (a) { break loop } }",
+		"{ if (a) { b() } }",
+		"{ x + 1 }",
+	}
+	for _, src := range blocks {
+		if _, ok := testhelpers.MustParse(t, src).Stmts[0].(*ast.BlockStmt); !ok {
+			t.Errorf("expected %q to parse as a block", src)
+		}
+	}
+
+	objects := []string{
+		"{}",
+		"{ a }",
+		"{ a, b }",
+		"{ a: 1 }",
+		"{ ...a }",
+		"{ default: 1 }",
+	}
+	for _, src := range objects {
+		if _, ok := testhelpers.MustParse(t, src).Stmts[0].(*ast.ObjectLiteral); !ok {
+			t.Errorf("expected %q to parse as an object literal", src)
+		}
+	}
+
+	inner := testhelpers.MustParse(t, "{ let x = { a: 1 }\n{ x } }").Stmts[0].(*ast.BlockStmt)
+	if len(inner.Body) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(inner.Body))
+	}
+	if _, ok := inner.Body[1].(*ast.ObjectLiteral); !ok {
+		t.Errorf("expected the rule to apply to nested statements, got %#v", inner.Body[1])
+	}
+
+	testhelpers.ExpectParseError(t, "{ let x = 1")
+}
+
+func TestOperatorPrecedence(t *testing.T) {
+	// Each input is compared against its fully parenthesized form
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"-a", "(-a)"},
+		{"-a * b", "((-a) * b)"},
+		{"- -a", "(-(-a))"},
+		{"(-2) ** 2", "((-2) ** 2)"},
+		{"-(2 ** 2)", "(-(2 ** 2))"},
+		{"2 ** -1", "(2 ** (-1))"},
+		{"2 ** 3 ** 2", "(2 ** (3 ** 2))"},
+		{"a * b ** c", "(a * (b ** c))"},
+		{"~a & b", "((~a) & b)"},
+		{"a | b ^ c & d", "(a | (b ^ (c & d)))"},
+		{"a & b | c", "((a & b) | c)"},
+		{"a << 1 + b", "(a << (1 + b))"},
+		{"typeof a == b", "((typeof a) == b)"},
+		{"typeof -a", "(typeof (-a))"},
+		{"typeof {}", "(typeof {})"},
+		{"typeof { a: 1 } == b", "((typeof {a}) == b)"},
+		{"!{}", "(!{})"},
+		{`"a" in { a: 1 }`, "(a in {a})"},
+		{"a in {}", "(a in {})"},
+		{"a + 1 in b", "((a + 1) in b)"},
+		{"a instanceof B && c", "((a instanceof B) && c)"},
+		{"a + b << c >> d", "(((a + b) << c) >> d)"},
+		{"a & 1 == 0", "(a & (1 == 0))"},
+		{"(a & 1) == 0", "((a & 1) == 0)"},
+		{"a == b & c", "((a == b) & c)"},
+		{"a | b == c ^ d", "(a | ((b == c) ^ d))"},
+		{"a << 1 < b", "((a << 1) < b)"},
+		{"a < b == c < d", "((a < b) == (c < d))"},
+		{"a < b < c", "((a < b) < c)"},
+		{"a == b != c", "((a == b) != c)"},
+		{`"a" in { a: 1 } == b`, "((a in {a}) == b)"},
+		{"a instanceof B == c", "((a instanceof B) == c)"},
+		{"!-a", "(!(-a))"},
+		{"-a.b", "(-a.b)"},
+		{"-f(1)", "(-f(1))"},
+		{"a += b * c", "(a += (b * c))"},
+		{"a.b ??= c || d", "(a.b ??= (c || d))"},
+		{"a = b -= 1", "(a = (b -= 1))"},
+		{"-a++", "(-(a++))"},
+		{"++a ** 2", "((++a) ** 2)"},
+		{"a++ * --b", "((a++) * (--b))"},
+		{"!a.b--", "(!(a.b--))"},
+		{"a ? b : c", "(a ? b : c)"},
+		{"a ? b : c ? d : e", "(a ? b : (c ? d : e))"},
+		{"a ? b ? c : d : e", "(a ? (b ? c : d) : e)"},
+		{"a || b ? c : d", "((a || b) ? c : d)"},
+		{"a ?? b ? c ?? d : e", "((a ?? b) ? (c ?? d) : e)"},
+		{"x = a ? b : c", "(x = (a ? b : c))"},
+		{"a ? x = b : x = c", "(a ? (x = b) : (x = c))"},
+		{"a == 1 ? -b : c + 1", "((a == 1) ? (-b) : (c + 1))"},
+	}
+
+	for _, test := range tests {
+		prog := testhelpers.MustParse(t, test.input)
+		if got := formatExpr(prog.Stmts[0]); got != test.expected {
+			t.Errorf("input=%q: expected %s, got %s", test.input, test.expected, got)
+		}
+	}
+
+	// Like in JavaScript, a unary expression cannot be the base of `**`
+	for _, input := range []string{"-2 ** 2", "!a ** 2", "typeof a ** 2", "2 ** -a ** 2"} {
+		if _, err := parser.New("test").ProduceAST(input); err == nil {
+			t.Errorf("input=%q: expected a syntax error", input)
+		}
+	}
+}
+
+// formatExpr renders the operators of an expression fully parenthesized
+func formatExpr(node ast.Stmt) string {
+	switch n := node.(type) {
+	case *ast.BinaryExpr:
+		return "(" + formatExpr(n.LHS) + " " + string(n.Operator) + " " + formatExpr(n.RHS) + ")"
+	case *ast.CompareExpr:
+		return "(" + formatExpr(n.LHS) + " " + string(n.Operator) + " " + formatExpr(n.RHS) + ")"
+	case *ast.UnaryExpr:
+		if n.Operator == ast.TypeOf {
+			return "(typeof " + formatExpr(n.Operand) + ")"
+		}
+		return "(" + string(n.Operator) + formatExpr(n.Operand) + ")"
+	case *ast.LogicalExpr:
+		if n.LHS != nil {
+			return "(" + formatExpr(*n.LHS) + " " + string(n.Operator) + " " + formatExpr(n.RHS) + ")"
+		}
+		return "(" + string(n.Operator) + formatExpr(n.RHS) + ")"
+	case *ast.VarAssignmentExpr:
+		operator := string(n.Operator)
+		if operator == "" {
+			operator = "="
+		}
+		return "(" + formatExpr(n.Assignee) + " " + operator + " " + formatExpr(n.Value) + ")"
+	case *ast.ConditionalExpr:
+		return "(" + formatExpr(n.Condition) + " ? " + formatExpr(n.Consequent) + " : " + formatExpr(n.Alternate) + ")"
+	case *ast.UpdateExpr:
+		if n.Prefix {
+			return "(" + string(n.Operator) + formatExpr(n.Operand) + ")"
+		}
+		return "(" + formatExpr(n.Operand) + string(n.Operator) + ")"
+	case *ast.Identifier:
+		return n.Symbol
+	case *ast.StringLiteral:
+		return n.Value
+	case *ast.ObjectLiteral:
+		keys := []string{}
+		for _, property := range n.Properties {
+			keys = append(keys, property.Key)
+		}
+		return "{" + strings.Join(keys, ", ") + "}"
+	case *ast.NumericLiteral:
+		return fmt.Sprint(n.Value)
+	case *ast.CallExpr:
+		return formatExpr(n.Callee) + "(" + fmt.Sprint(len(n.Args)) + ")"
+	case *ast.MemberExpr:
+		return formatExpr(n.Object) + "." + formatExpr(n.Value)
+	}
+	return "?"
+}
+
+func TestStaticClassMembers(t *testing.T) {
+	class := testhelpers.MustParse(t, "class C { public static x = 1\nprivate static m() {}\npublic y }").Stmts[0].(*ast.Class)
+	if len(class.Body) != 3 {
+		t.Fatalf("expected 3 members, got %d", len(class.Body))
+	}
+
+	if property := class.Body[0].(*ast.ClassProperty); !property.IsStatic || !property.IsPublic {
+		t.Errorf("expected x to be public and static, got %#v", property)
+	}
+	if method := class.Body[1].(*ast.ClassMethod); !method.IsStatic || method.IsPublic {
+		t.Errorf("expected m to be private and static, got %#v", method)
+	}
+	if property := class.Body[2].(*ast.ClassProperty); property.IsStatic {
+		t.Errorf("expected y not to be static")
+	}
+
+	testhelpers.ExpectParseError(t, "class C { static x = 1 }")
+	testhelpers.ExpectParseError(t, "class C { public static constructor() {} }")
+}
+
+func TestClassAccessors(t *testing.T) {
+	class := testhelpers.MustParse(t, "class C { public get x() { return 1 }\npublic set x(v) {}\npublic get() {} }").Stmts[0].(*ast.Class)
+
+	getter := class.Body[0].(*ast.ClassMethod)
+	if getter.Name != "x" || getter.Accessor != ast.Getter {
+		t.Errorf("expected a getter for x, got %#v", getter)
+	}
+	setter := class.Body[1].(*ast.ClassMethod)
+	if setter.Name != "x" || setter.Accessor != ast.Setter || len(setter.Params) != 1 {
+		t.Errorf("expected a setter for x, got %#v", setter)
+	}
+	if method := class.Body[2].(*ast.ClassMethod); method.Name != "get" || method.Accessor != "" {
+		t.Errorf("expected a method called get, got %#v", method)
+	}
+
+	testhelpers.ExpectParseError(t, "class C { public get x(a) {} }")
+	testhelpers.ExpectParseError(t, "class C { public set x() {} }")
+	testhelpers.ExpectParseError(t, "class C { public set x(...v) {} }")
+	testhelpers.ExpectParseError(t, "class C { private get x() {} }")
+	testhelpers.ExpectParseError(t, "class C { public static get x() {} }")
+	testhelpers.ExpectParseError(t, "class C { public get x = 1 }")
+}
@@ -0,0 +1,182 @@
+package stdlib
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// memberNames returns the sorted property names of an object, or the
+// sorted public member names of a class instance.
+func memberNames(value shared.RuntimeValue) []string {
+	names := []string{}
+	switch value.Type {
+	case shared.Object:
+		for name := range value.Value.(map[string]*shared.RuntimeValue) {
+			names = append(names, name)
+		}
+	case shared.ClassInstance:
+		for name, isPublic := range value.Value.(values.ClassInstanceValue).Publics {
+			if isPublic {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func keysFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("keys", args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	keys := []shared.RuntimeValue{}
+	switch args[0].Type {
+	case shared.Object, shared.ClassInstance:
+		for _, name := range memberNames(args[0]) {
+			keys = append(keys, values.MK_STRING(name))
+		}
+	case shared.Array:
+		for i := range args[0].Value.([]shared.RuntimeValue) {
+			keys = append(keys, values.MK_NUMBER(float64(i)))
+		}
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("keys() expects an object, class instance or array, got %s.", shared.Stringify(args[0].Type)),
+		}
+	}
+	return ptr(values.MK_ARRAY(keys)), nil
+}
+
+func valuesFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("values", args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	result := []shared.RuntimeValue{}
+	switch args[0].Type {
+	case shared.Object:
+		properties := args[0].Value.(map[string]*shared.RuntimeValue)
+		for _, name := range memberNames(args[0]) {
+			result = append(result, *properties[name])
+		}
+	case shared.ClassInstance:
+		data := args[0].Value.(values.ClassInstanceValue).Data
+		for _, name := range memberNames(args[0]) {
+			value, err := data.LookupVar(name)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, *value)
+		}
+	case shared.Array:
+		result = append(result, args[0].Value.([]shared.RuntimeValue)...)
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("values() expects an object, class instance or array, got %s.", shared.Stringify(args[0].Type)),
+		}
+	}
+	return ptr(values.MK_ARRAY(result)), nil
+}
+
+func pushFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("push", args, 1, -1, shared.Array); err != nil {
+		return nil, err
+	}
+
+	elements := args[0].Value.([]shared.RuntimeValue)
+	result := make([]shared.RuntimeValue, 0, len(elements)+len(args)-1)
+	result = append(result, elements...)
+	result = append(result, args[1:]...)
+	return ptr(values.MK_ARRAY(result)), nil
+}
+
+// lastElementFn returns the native named name giving the last element of an
+// array, nil when it is empty. Arrays are passed by value, so the argument is
+// left as is.
+func lastElementFn(name string) func([]shared.RuntimeValue, *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	return func(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+		if err := expectArgs(name, args, 1, 1, shared.Array); err != nil {
+			return nil, err
+		}
+
+		elements := args[0].Value.([]shared.RuntimeValue)
+		if len(elements) == 0 {
+			return ptr(values.MK_NIL()), nil
+		}
+		return ptr(elements[len(elements)-1]), nil
+	}
+}
+
+func sliceFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("slice", args, 2, 3); err != nil {
+		return nil, err
+	}
+
+	var length int
+	switch args[0].Type {
+	case shared.Array:
+		length = len(args[0].Value.([]shared.RuntimeValue))
+	case shared.String:
+		length = len([]rune(args[0].Value.(string)))
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("slice() expects an array or string, got %s.", shared.Stringify(args[0].Type)),
+		}
+	}
+
+	start, err := sliceIndex("start", args[1], length)
+	if err != nil {
+		return nil, err
+	}
+	end := length
+	if len(args) == 3 {
+		if end, err = sliceIndex("end", args[2], length); err != nil {
+			return nil, err
+		}
+	}
+	if end < start {
+		end = start
+	}
+
+	if args[0].Type == shared.String {
+		return ptr(values.MK_STRING(string([]rune(args[0].Value.(string))[start:end]))), nil
+	}
+	result := make([]shared.RuntimeValue, end-start)
+	copy(result, args[0].Value.([]shared.RuntimeValue)[start:end])
+	return ptr(values.MK_ARRAY(result)), nil
+}
+
+// sliceIndex resolves a possibly negative index against length and clamps
+// it to [0, length].
+func sliceIndex(name string, arg shared.RuntimeValue, length int) (int, *errors.RuntimeError) {
+	if arg.Type != shared.Number {
+		return 0, &errors.RuntimeError{
+			Message: fmt.Sprintf("slice() expects %s to be a number, got %s.", name, shared.Stringify(arg.Type)),
+		}
+	}
+
+	value := arg.Value.(float64)
+	switch {
+	case math.IsNaN(value):
+		return 0, &errors.RuntimeError{
+			Message: fmt.Sprintf("slice() expects %s to be a number, got NaN.", name),
+		}
+	case math.IsInf(value, 1):
+		return length, nil
+	case math.IsInf(value, -1):
+		return 0, nil
+	}
+
+	index := int(value)
+	if index < 0 {
+		index += length
+	}
+	return max(0, min(index, length)), nil
+}
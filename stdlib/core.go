@@ -0,0 +1,80 @@
+package stdlib
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/helpers"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+func printFn(stdout io.Writer) values.NativeFunction {
+	return func(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+		parts := make([]string, len(args))
+		for i := range args {
+			parts[i] = helpers.ToString(&args[i])
+		}
+		if _, err := fmt.Fprintln(stdout, strings.Join(parts, " ")); err != nil {
+			return nil, &errors.RuntimeError{Message: fmt.Sprintf("print() failed: %s", err)}
+		}
+		return ptr(values.MK_NIL()), nil
+	}
+}
+
+func lenFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("len", args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch args[0].Type {
+	case shared.String:
+		return ptr(values.MK_NUMBER(float64(utf8.RuneCountInString(args[0].Value.(string))))), nil
+	case shared.Array:
+		return ptr(values.MK_NUMBER(float64(len(args[0].Value.([]shared.RuntimeValue))))), nil
+	case shared.Object:
+		return ptr(values.MK_NUMBER(float64(len(args[0].Value.(map[string]*shared.RuntimeValue))))), nil
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("len() expects a string, array or object, got %s.", shared.Stringify(args[0].Type)),
+		}
+	}
+}
+
+func strFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("str", args, 1, 1); err != nil {
+		return nil, err
+	}
+	return ptr(values.MK_STRING(helpers.ToString(&args[0]))), nil
+}
+
+// numFn converts numbers, numeric strings and booleans to a number and
+// returns nil for anything else.
+func numFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("num", args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch args[0].Type {
+	case shared.Number:
+		return ptr(args[0]), nil
+	case shared.Boolean:
+		if args[0].Value.(bool) {
+			return ptr(values.MK_NUMBER(1)), nil
+		}
+		return ptr(values.MK_NUMBER(0)), nil
+	case shared.String:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(args[0].Value.(string)), 64)
+		if err != nil {
+			return ptr(values.MK_NIL()), nil
+		}
+		return ptr(values.MK_NUMBER(parsed)), nil
+	default:
+		return ptr(values.MK_NIL()), nil
+	}
+}
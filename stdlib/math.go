@@ -0,0 +1,66 @@
+package stdlib
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+func mathObject() shared.RuntimeValue {
+	return values.MK_OBJECT(map[string]*shared.RuntimeValue{
+		"floor":  ptr(values.MK_NATIVE_FN(unaryMathFn("Math.floor", math.Floor))),
+		"ceil":   ptr(values.MK_NATIVE_FN(unaryMathFn("Math.ceil", math.Ceil))),
+		"abs":    ptr(values.MK_NATIVE_FN(unaryMathFn("Math.abs", math.Abs))),
+		"sqrt":   ptr(values.MK_NATIVE_FN(unaryMathFn("Math.sqrt", math.Sqrt))),
+		"pow":    ptr(values.MK_NATIVE_FN(mathPowFn)),
+		"min":    ptr(values.MK_NATIVE_FN(extremumFn("Math.min", math.Min))),
+		"max":    ptr(values.MK_NATIVE_FN(extremumFn("Math.max", math.Max))),
+		"random": ptr(values.MK_NATIVE_FN(mathRandomFn)),
+	})
+}
+
+func unaryMathFn(name string, op func(float64) float64) values.NativeFunction {
+	return func(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+		if err := expectArgs(name, args, 1, 1, shared.Number); err != nil {
+			return nil, err
+		}
+		return ptr(values.MK_NUMBER(op(args[0].Value.(float64)))), nil
+	}
+}
+
+func mathPowFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("Math.pow", args, 2, 2, shared.Number, shared.Number); err != nil {
+		return nil, err
+	}
+	return ptr(values.MK_NUMBER(math.Pow(args[0].Value.(float64), args[1].Value.(float64)))), nil
+}
+
+// extremumFn folds one or more numbers with op.
+func extremumFn(name string, op func(float64, float64) float64) values.NativeFunction {
+	return func(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+		types := make([]shared.ValueType, len(args))
+		for i := range types {
+			types[i] = shared.Number
+		}
+		if err := expectArgs(name, args, 1, -1, types...); err != nil {
+			return nil, err
+		}
+
+		result := args[0].Value.(float64)
+		for _, arg := range args[1:] {
+			result = op(result, arg.Value.(float64))
+		}
+		return ptr(values.MK_NUMBER(result)), nil
+	}
+}
+
+func mathRandomFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("Math.random", args, 0, 0); err != nil {
+		return nil, err
+	}
+	return ptr(values.MK_NUMBER(rand.Float64())), nil
+}
@@ -0,0 +1,115 @@
+// Package stdlib provides an opt-in standard library of native globals for
+// VirtLang scripts. Nothing is installed unless the embedder calls Install.
+//
+// Installed globals:
+//
+//	true, false, nil              constants
+//	print(...values)              writes values separated by spaces and a newline
+//	len(value)                    length of a string, array or object
+//	str(value)                    string conversion (see helpers.ToString)
+//	num(value)                    number conversion, nil when not convertible
+//	Math                          floor, ceil, abs, sqrt, pow, min, max, random
+//	keys(value)                   keys of an object, class instance or array
+//	values(value)                 values of an object, class instance or array
+//	push(array, ...values)        new array with values appended
+//	pop(array), last(array)       last element of an array, nil when empty
+//	slice(value, start, end?)     sub-array or substring, negative indices count from the end,
+//	                              infinite ones clamp to either end
+//	split(string, separator)      array of substrings
+//	join(array, separator)        string of str()-converted elements
+//	upper(string), lower(string)  case conversion
+//	trim(string)                  string without leading and trailing whitespace
+//	replace(string, old, new)     string with every occurrence of old replaced
+//
+// Arrays are passed to native functions by value, so push and pop never
+// modify their argument: `arr = push(arr, 1)`, and `stack = slice(stack, 0, -1)`
+// drops the element pop returned.
+//
+// There is no typeof global, the typeof operator gives the type name of a
+// value.
+package stdlib
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// Install declares every stdlib global as a constant in env. Output of
+// `print` goes to stdout, or os.Stdout when stdout is nil.
+func Install(env *environment.Environment, stdout io.Writer) *errors.RuntimeError {
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	globals := map[string]shared.RuntimeValue{
+		"true":  values.MK_BOOL(true),
+		"false": values.MK_BOOL(false),
+		"nil":   values.MK_NIL(),
+
+		"print": values.MK_NATIVE_FN(printFn(stdout)),
+		"len":   values.MK_NATIVE_FN(lenFn),
+		"str":   values.MK_NATIVE_FN(strFn),
+		"num":   values.MK_NATIVE_FN(numFn),
+		"Math":  mathObject(),
+
+		"keys":   values.MK_NATIVE_FN(keysFn),
+		"values": values.MK_NATIVE_FN(valuesFn),
+		"push":   values.MK_NATIVE_FN(pushFn),
+		"pop":    values.MK_NATIVE_FN(lastElementFn("pop")),
+		"last":   values.MK_NATIVE_FN(lastElementFn("last")),
+		"slice":  values.MK_NATIVE_FN(sliceFn),
+
+		"split":   values.MK_NATIVE_FN(splitFn),
+		"join":    values.MK_NATIVE_FN(joinFn),
+		"upper":   values.MK_NATIVE_FN(upperFn),
+		"lower":   values.MK_NATIVE_FN(lowerFn),
+		"trim":    values.MK_NATIVE_FN(trimFn),
+		"replace": values.MK_NATIVE_FN(replaceFn),
+	}
+
+	for name, value := range globals {
+		if _, err := env.DeclareVar(name, value, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expectArgs checks the argument count and the type of each leading
+// argument. Trailing arguments beyond len(types) are left unchecked.
+func expectArgs(name string, args []shared.RuntimeValue, minArgs, maxArgs int, types ...shared.ValueType) *errors.RuntimeError {
+	if len(args) < minArgs || (maxArgs >= 0 && len(args) > maxArgs) {
+		expected := fmt.Sprintf("%d", minArgs)
+		switch {
+		case maxArgs < 0:
+			expected = fmt.Sprintf("at least %d", minArgs)
+		case maxArgs != minArgs:
+			expected = fmt.Sprintf("%d to %d", minArgs, maxArgs)
+		}
+		return &errors.RuntimeError{
+			Message: fmt.Sprintf("%s() expects %s argument(s), got %d.", name, expected, len(args)),
+		}
+	}
+
+	for i, expected := range types {
+		if i >= len(args) {
+			break
+		}
+		if args[i].Type != expected {
+			return &errors.RuntimeError{
+				Message: fmt.Sprintf("%s() expects argument %d to be a %s, got %s.", name, i+1, shared.Stringify(expected), shared.Stringify(args[i].Type)),
+			}
+		}
+	}
+	return nil
+}
+
+func ptr(value shared.RuntimeValue) *shared.RuntimeValue {
+	return &value
+}
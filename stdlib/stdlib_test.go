@@ -0,0 +1,189 @@
+package stdlib_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/evaluator"
+	"github.com/dev-kas/virtlang-go/v4/parser"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/stdlib"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+func run(t *testing.T, input string) (*shared.RuntimeValue, string, error) {
+	t.Helper()
+
+	p := parser.New("test")
+	program, synErr := p.ProduceAST(input)
+	if synErr != nil {
+		t.Fatalf("parse error: input=%q, error=%v", input, synErr)
+	}
+
+	stdout := &bytes.Buffer{}
+	env := environment.NewEnvironment(nil)
+	if err := stdlib.Install(env, stdout); err != nil {
+		t.Fatalf("install error: %v", err)
+	}
+
+	result, err := evaluator.Evaluate(program, env, nil)
+	if err != nil {
+		return nil, stdout.String(), err
+	}
+	return result, stdout.String(), nil
+}
+
+func arr(elements ...shared.RuntimeValue) shared.RuntimeValue {
+	if elements == nil {
+		elements = []shared.RuntimeValue{}
+	}
+	return values.MK_ARRAY(elements)
+}
+
+func TestStdlib(t *testing.T) {
+	tests := []struct {
+		input  string
+		output shared.RuntimeValue
+	}{
+		// constants
+		{"true", values.MK_BOOL(true)},
+		{"false", values.MK_BOOL(false)},
+		{"nil", values.MK_NIL()},
+
+		// len
+		{`len("héllo")`, values.MK_NUMBER(5)},
+		{"len([1, 2, 3])", values.MK_NUMBER(3)},
+		{"len({a: 1, b: 2})", values.MK_NUMBER(2)},
+
+		// str
+		{"str(42)", values.MK_STRING("42")},
+		{"str(1.5)", values.MK_STRING("1.5")},
+		{"str(true)", values.MK_STRING("true")},
+		{"str(nil)", values.MK_STRING("nil")},
+		{`str([1, "a", [true]])`, values.MK_STRING(`[1, "a", [true]]`)},
+		{`str({b: 2, a: "x"})`, values.MK_STRING(`{a: "x", b: 2}`)},
+
+		// num
+		{`num("3.25")`, values.MK_NUMBER(3.25)},
+		{`num(" 7 ")`, values.MK_NUMBER(7)},
+		{"num(true)", values.MK_NUMBER(1)},
+		{"num(5)", values.MK_NUMBER(5)},
+		{`num("abc")`, values.MK_NIL()},
+		{"num([])", values.MK_NIL()},
+
+		// Math
+		{"Math.floor(1.7)", values.MK_NUMBER(1)},
+		{"Math.ceil(1.2)", values.MK_NUMBER(2)},
+		{"Math.abs(0 - 4)", values.MK_NUMBER(4)},
+		{"Math.sqrt(16)", values.MK_NUMBER(4)},
+		{"Math.pow(2, 10)", values.MK_NUMBER(1024)},
+		{"Math.min(3, 1, 2)", values.MK_NUMBER(1)},
+		{"Math.max(3, 1, 2)", values.MK_NUMBER(3)},
+		{"let r = Math.random() r >= 0 && r < 1", values.MK_BOOL(true)},
+
+		// keys / values
+		{"keys({b: 1, a: 2})", arr(values.MK_STRING("a"), values.MK_STRING("b"))},
+		{"keys([5, 6])", arr(values.MK_NUMBER(0), values.MK_NUMBER(1))},
+		{"values({b: 1, a: 2})", arr(values.MK_NUMBER(2), values.MK_NUMBER(1))},
+		{"values([5, 6])", arr(values.MK_NUMBER(5), values.MK_NUMBER(6))},
+		{`class P { public name public constructor() { name = "x" } } keys(P())`, arr(values.MK_STRING("name"))},
+		{`class P { public name public constructor() { name = "x" } } values(P())`, arr(values.MK_STRING("x"))},
+
+		// push / pop / last
+		{"push([1], 2, 3)", arr(values.MK_NUMBER(1), values.MK_NUMBER(2), values.MK_NUMBER(3))},
+		{"let a = [1] push(a, 2) a", arr(values.MK_NUMBER(1))},
+		{"pop([1, 2])", values.MK_NUMBER(2)},
+		{"pop([])", values.MK_NIL()},
+		{"let a = [1, 2]\npop(a)\na", arr(values.MK_NUMBER(1), values.MK_NUMBER(2))},
+		{"let s = [1, 2]\nlet top = pop(s)\ns = slice(s, 0, -1)\npush(s, top)", arr(values.MK_NUMBER(1), values.MK_NUMBER(2))},
+		{"last([1, 2])", values.MK_NUMBER(2)},
+		{"last([])", values.MK_NIL()},
+		{"let a = [1, 2]\nlast(a)\na", arr(values.MK_NUMBER(1), values.MK_NUMBER(2))},
+
+		// slice
+		{"slice([1, 2, 3, 4], 1, 3)", arr(values.MK_NUMBER(2), values.MK_NUMBER(3))},
+		{"slice([1, 2, 3], 0 - 1)", arr(values.MK_NUMBER(3))},
+		{"slice([1, 2, 3], 2, 1)", arr()},
+		{`slice("hello", 1, 3)`, values.MK_STRING("el")},
+		{`slice("hello", 0 - 3)`, values.MK_STRING("llo")},
+		{"slice([1, 2, 3], 1, Math.pow(10, 400))", arr(values.MK_NUMBER(2), values.MK_NUMBER(3))},
+		{"slice([1, 2, 3], 0 - Math.pow(10, 400), 1)", arr(values.MK_NUMBER(1))},
+
+		// strings
+		{`split("a,b,c", ",")`, arr(values.MK_STRING("a"), values.MK_STRING("b"), values.MK_STRING("c"))},
+		{`join([1, "a", true], "-")`, values.MK_STRING("1-a-true")},
+		{`upper("abC")`, values.MK_STRING("ABC")},
+		{`lower("AbC")`, values.MK_STRING("abc")},
+		{`trim("  hi  ")`, values.MK_STRING("hi")},
+		{`replace("a-b-c", "-", "+")`, values.MK_STRING("a+b+c")},
+	}
+
+	for i, test := range tests {
+		result, _, err := run(t, test.input)
+		if err != nil {
+			t.Errorf("test %d failed: input=%q, unexpected error: %v", i, test.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(*result, test.output) {
+			t.Errorf("test %d failed: input=%q, expected=%v, got=%v", i, test.input, test.output, *result)
+		}
+	}
+}
+
+func TestStdlibPrint(t *testing.T) {
+	result, stdout, err := run(t, `print("a", 1, [2, "b"], nil) print()`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "a 1 [2, \"b\"] nil\n\n"; stdout != expected {
+		t.Errorf("expected output %q, got %q", expected, stdout)
+	}
+	if !reflect.DeepEqual(*result, values.MK_NIL()) {
+		t.Errorf("expected print to return nil, got %v", *result)
+	}
+}
+
+func TestStdlibErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+	}{
+		{"len(1)", "len() expects a string, array or object, got number."},
+		{"len()", "len() expects 1 argument(s), got 0."},
+		{"Math.floor(\"1\")", "Math.floor() expects argument 1 to be a number, got string."},
+		{"Math.max()", "Math.max() expects at least 1 argument(s), got 0."},
+		{"push(1, 2)", "push() expects argument 1 to be a array, got number."},
+		{"slice([1], \"a\")", "slice() expects start to be a number, got string."},
+		{"slice([1])", "slice() expects 2 to 3 argument(s), got 1."},
+		{"slice([1], Math.sqrt(0 - 1))", "slice() expects start to be a number, got NaN."},
+		{"pop(1)", "pop() expects argument 1 to be a array, got number."},
+		{"last(1)", "last() expects argument 1 to be a array, got number."},
+		{"keys(1)", "keys() expects an object, class instance or array, got number."},
+		{"upper(1)", "upper() expects argument 1 to be a string, got number."},
+		{"len = 1", "Cannot reassign to constant variable `len`"},
+	}
+
+	for i, test := range tests {
+		_, _, err := run(t, test.input)
+		if err == nil {
+			t.Errorf("test %d failed: input=%q, expected an error", i, test.input)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.message) {
+			t.Errorf("test %d failed: input=%q, expected error containing %q, got %q", i, test.input, test.message, err.Error())
+		}
+	}
+}
+
+func TestInstallTwice(t *testing.T) {
+	env := environment.NewEnvironment(nil)
+	if err := stdlib.Install(env, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := stdlib.Install(env, nil); err == nil {
+		t.Errorf("expected redeclaration error on second install")
+	}
+}
@@ -0,0 +1,59 @@
+package stdlib
+
+import (
+	"strings"
+
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/helpers"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+func splitFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("split", args, 2, 2, shared.String, shared.String); err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(args[0].Value.(string), args[1].Value.(string))
+	result := make([]shared.RuntimeValue, len(parts))
+	for i, part := range parts {
+		result[i] = values.MK_STRING(part)
+	}
+	return ptr(values.MK_ARRAY(result)), nil
+}
+
+func joinFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("join", args, 2, 2, shared.Array, shared.String); err != nil {
+		return nil, err
+	}
+
+	elements := args[0].Value.([]shared.RuntimeValue)
+	parts := make([]string, len(elements))
+	for i := range elements {
+		parts[i] = helpers.ToString(&elements[i])
+	}
+	return ptr(values.MK_STRING(strings.Join(parts, args[1].Value.(string)))), nil
+}
+
+func stringFn(name string, op func(string) string) values.NativeFunction {
+	return func(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+		if err := expectArgs(name, args, 1, 1, shared.String); err != nil {
+			return nil, err
+		}
+		return ptr(values.MK_STRING(op(args[0].Value.(string)))), nil
+	}
+}
+
+var (
+	upperFn = stringFn("upper", strings.ToUpper)
+	lowerFn = stringFn("lower", strings.ToLower)
+	trimFn  = stringFn("trim", strings.TrimSpace)
+)
+
+func replaceFn(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if err := expectArgs("replace", args, 3, 3, shared.String, shared.String, shared.String); err != nil {
+		return nil, err
+	}
+	return ptr(values.MK_STRING(strings.ReplaceAll(args[0].Value.(string), args[1].Value.(string), args[2].Value.(string)))), nil
+}
@@ -1,6 +1,8 @@
 package values
 
 import (
+	"sync/atomic"
+
 	"github.com/dev-kas/virtlang-go/v4/ast"
 	"github.com/dev-kas/virtlang-go/v4/environment"
 	"github.com/dev-kas/virtlang-go/v4/errors"
@@ -63,9 +65,9 @@ type FunctionValue struct {
 	Type           shared.ValueType
 	Value          any
 	Name           string
-	Params         []string
 	DeclarationEnv *environment.Environment
 	Body           []ast.Stmt
+	ast.Signature
 }
 
 func MK_ARRAY(value []shared.RuntimeValue) shared.RuntimeValue {
@@ -82,6 +84,18 @@ type ClassValue struct {
 	Body           []ast.Stmt
 	DeclarationEnv *environment.Environment
 	Constructor    *ast.ClassMethod
+	Parent         *ClassValue              // Superclass, nil for base classes
+	Statics        *environment.Environment // Static members, evaluated once when the class is declared
+	StaticPublics  map[string]bool          // Whether each static member, inherited ones included, is public
+	ID             uint64                   // Identity of the class, shared by every copy of its value
+}
+
+var lastClassID atomic.Uint64
+
+// NewClassID returns an identity no other class has, class values being
+// copied around
+func NewClassID() uint64 {
+	return lastClassID.Add(1)
 }
 
 func MK_CLASS(name string, body []ast.Stmt, constructor *ast.ClassMethod, declarationEnv *environment.Environment) shared.RuntimeValue {
@@ -94,15 +108,34 @@ func MK_CLASS(name string, body []ast.Stmt, constructor *ast.ClassMethod, declar
 			Body:           body,
 			DeclarationEnv: declarationEnv,
 			Constructor:    constructor,
+			ID:             NewClassID(),
 		},
 	}
 }
 
 type ClassInstanceValue struct {
-	Type    shared.ValueType
-	Class   ClassValue
-	Publics map[string]bool
-	Data    *environment.Environment
+	Type      shared.ValueType
+	Class     ClassValue
+	Publics   map[string]bool
+	Data      *environment.Environment
+	Accessors map[string]Accessor // Getters and setters, inherited ones included
+}
+
+// Accessor holds the getter and setter of a class instance property, either
+// of which may be nil
+type Accessor struct {
+	Get *shared.RuntimeValue
+	Set *shared.RuntimeValue
+}
+
+// With returns a copy of the accessor using fn as its getter or setter
+func (a Accessor) With(kind ast.AccessorKind, fn shared.RuntimeValue) Accessor {
+	if kind == ast.Getter {
+		a.Get = &fn
+	} else {
+		a.Set = &fn
+	}
+	return a
 }
 
 func MK_CLASS_INSTANCE(class *ClassValue, publics map[string]bool, data *environment.Environment) shared.RuntimeValue {
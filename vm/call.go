@@ -0,0 +1,135 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/dev-kas/virtlang-go/v4/compiler"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// closure is the payload of the function values created by the vm, stored
+// in values.FunctionValue.Value
+type closure struct {
+	fn    *compiler.Function
+	scope *scope
+}
+
+func makeClosure(fn *compiler.Function, sc *scope) shared.RuntimeValue {
+	return shared.RuntimeValue{
+		Type: shared.Function,
+		Value: &values.FunctionValue{
+			Type:           shared.Function,
+			Value:          &closure{fn: fn, scope: sc},
+			Name:           fn.Name,
+			Signature:      fn.Signature,
+			DeclarationEnv: sc.environment(),
+			Body:           fn.Body,
+		},
+	}
+}
+
+func (m *machine) call(callee *shared.RuntimeValue, args []*shared.RuntimeValue, sc *scope, site compiler.Site) (*shared.RuntimeValue, *errors.RuntimeError) {
+	switch callee.Type {
+	case shared.NativeFN:
+		native, ok := callee.Value.(values.NativeFunction)
+		if !ok {
+			return nil, &errors.RuntimeError{
+				Message: fmt.Sprintf("Unable to resolve native function type: %s.", shared.Stringify(callee.Type)),
+			}
+		}
+		converted := make([]shared.RuntimeValue, len(args))
+		for i, arg := range args {
+			converted[i] = *arg
+		}
+		return native(converted, sc.environment())
+
+	case shared.Function:
+		fnVal, ok := callee.Value.(*values.FunctionValue)
+		if !ok {
+			return nil, &errors.RuntimeError{
+				Message: fmt.Sprintf("Expected function value, got %T", callee.Value),
+			}
+		}
+		cl, ok := fnVal.Value.(*closure)
+		if !ok {
+			return nil, &errors.RuntimeError{
+				Message: fmt.Sprintf("Expected function value, got %T", fnVal.Value),
+			}
+		}
+
+		result, err := m.invoke(cl.fn, cl.scope, args, site)
+		if isControlFlow(err, errors.ICP_Return) {
+			return err.InternalCommunicationProtocol.RValue, nil
+		}
+		return result, strayControlFlow(err)
+
+	case shared.Class:
+		classVal := callee.Value.(values.ClassValue)
+		return m.instantiate(&classVal, args, site)
+
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot invoke a non-function (attempted to call a %s).", shared.Stringify(callee.Type)),
+		}
+	}
+}
+
+// invoke runs a compiled function called at site in a new scope holding its
+// parameters, missing arguments being nil and the remaining ones being
+// collected by the rest parameter
+func (m *machine) invoke(fn *compiler.Function, parent *scope, args []*shared.RuntimeValue, site compiler.Site) (*shared.RuntimeValue, *errors.RuntimeError) {
+	name := fn.Name
+	if fn.IsConstructor {
+		name = "constructor"
+	} else if name == "" {
+		name = "<anonymous>"
+	}
+	if err := m.EnterCall(errors.StackFrame{Name: name, Filename: site.Filename, Line: site.Line}); err != nil {
+		return nil, err
+	}
+	defer m.ExitCall()
+
+	sc := parent
+	if fn.Locals != nil {
+		sc = newScope(parent, fn.Locals)
+		for i, slot := range fn.ParamSlots {
+			// The first of duplicate parameters wins
+			if sc.slots[slot].value != nil {
+				continue
+			}
+			value := values.MK_NIL()
+			if i < len(args) {
+				value = *args[i]
+			}
+			sc.slots[slot] = binding{value: &value, constant: true}
+		}
+		if fn.RestSlot >= 0 && sc.slots[fn.RestSlot].value == nil {
+			rest := restArgs(args, len(fn.Params))
+			sc.slots[fn.RestSlot] = binding{value: &rest, constant: true}
+		}
+	}
+
+	return m.run(fn, sc, args)
+}
+
+// spreadArgs unpacks the array of arguments built for a call with spread
+// arguments
+func spreadArgs(array *shared.RuntimeValue) []*shared.RuntimeValue {
+	elements := array.Value.([]shared.RuntimeValue)
+	args := make([]*shared.RuntimeValue, len(elements))
+	for i := range elements {
+		args[i] = &elements[i]
+	}
+	return args
+}
+
+// restArgs collects the arguments from index start on into an array
+func restArgs(args []*shared.RuntimeValue, start int) shared.RuntimeValue {
+	remaining := []shared.RuntimeValue{}
+	for i := start; i < len(args); i++ {
+		remaining = append(remaining, *args[i])
+	}
+	return values.MK_ARRAY(remaining)
+}
@@ -0,0 +1,244 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/dev-kas/virtlang-go/v4/compiler"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// classPayload is the payload of the class values created by the vm, stored
+// in values.ClassValue.Value
+type classPayload struct {
+	class *compiler.Class
+	scope *scope // Scope the class was declared in
+}
+
+func (m *machine) makeClass(class *compiler.Class, parent *shared.RuntimeValue, sc *scope) (shared.RuntimeValue, *errors.RuntimeError) {
+	classVal := values.ClassValue{
+		Type:           shared.Class,
+		Value:          &classPayload{class: class, scope: sc},
+		Name:           class.Name,
+		Body:           class.Node.Body,
+		DeclarationEnv: sc.environment(),
+		Constructor:    class.Node.Constructor,
+		ID:             values.NewClassID(),
+	}
+
+	if parent != nil {
+		if parent.Type != shared.Class {
+			return shared.RuntimeValue{}, &errors.RuntimeError{
+				Message: fmt.Sprintf("Class `%s` cannot extend a non-class (attempted to extend a %s).", class.Name, shared.Stringify(parent.Type)),
+			}
+		}
+		parentVal := parent.Value.(values.ClassValue)
+		classVal.Parent = &parentVal
+	}
+
+	if err := m.buildStatics(&classVal); err != nil {
+		return shared.RuntimeValue{}, err
+	}
+
+	return shared.RuntimeValue{Type: shared.Class, Value: classVal}, nil
+}
+
+// buildStatics runs the static members of a class once, on top of the static
+// environment of its parent, mirroring the evaluator.
+func (m *machine) buildStatics(classVal *values.ClassValue) *errors.RuntimeError {
+	payload, err := payloadOf(classVal)
+	if err != nil {
+		return err
+	}
+
+	var parentEnv *environment.Environment
+	publics := map[string]bool{}
+
+	if classVal.Parent != nil && classVal.Parent.Statics != nil {
+		parentEnv = classVal.Parent.Statics
+		for name, isPublic := range classVal.Parent.StaticPublics {
+			publics[name] = isPublic
+		}
+	}
+
+	env := environment.NewEnvironment(parentEnv)
+	if err := m.declareMembers(payload.class.Statics, classScope(payload, env), publics, nil); err != nil {
+		return err
+	}
+
+	classVal.Statics = env
+	classVal.StaticPublics = publics
+	return nil
+}
+
+func payloadOf(classVal *values.ClassValue) (*classPayload, *errors.RuntimeError) {
+	payload, ok := classVal.Value.(*classPayload)
+	if !ok {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Class `%s` was not created by the vm.", classVal.Name),
+		}
+	}
+	return payload, nil
+}
+
+// classScope is the scope members of a class run in: its environment, on
+// top of the scope the class was declared in
+func classScope(payload *classPayload, env *environment.Environment) *scope {
+	return &scope{parent: payload.scope, env: env}
+}
+
+func (m *machine) instantiate(classVal *values.ClassValue, args []*shared.RuntimeValue, site compiler.Site) (*shared.RuntimeValue, *errors.RuntimeError) {
+	env, publics, accessors, err := m.buildClassScope(classVal)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.runConstructor(classVal, env, args, site); err != nil {
+		return nil, err
+	}
+
+	instance := instanceWithAccessors(values.MK_CLASS_INSTANCE(classVal, publics, env), accessors)
+	return &instance, nil
+}
+
+// buildClassScope runs the members of a class on top of the environments of
+// its ancestors, mirroring the evaluator.
+func (m *machine) buildClassScope(classVal *values.ClassValue) (*environment.Environment, map[string]bool, map[string]values.Accessor, *errors.RuntimeError) {
+	payload, err := payloadOf(classVal)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var parentEnv *environment.Environment
+	publics := map[string]bool{}
+	accessors := map[string]values.Accessor{}
+
+	var superVal *shared.RuntimeValue
+	if classVal.Parent != nil {
+		parentScope, parentPublics, parentAccessors, err := m.buildClassScope(classVal.Parent)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		superPublics := map[string]bool{}
+		for name, isPublic := range parentPublics {
+			publics[name] = isPublic
+			superPublics[name] = true
+		}
+		for name, accessor := range parentAccessors {
+			accessors[name] = accessor
+		}
+
+		super := instanceWithAccessors(values.MK_CLASS_INSTANCE(classVal.Parent, superPublics, parentScope), parentAccessors)
+		superVal = &super
+		parentEnv = parentScope
+	}
+
+	// The scope the class was declared in is reached through the vm scope
+	// chain, not the environment
+	env := environment.NewEnvironment(parentEnv)
+	if superVal != nil {
+		if _, err := env.DeclareVar("super", *superVal, true); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if err := m.declareMembers(payload.class.Members, classScope(payload, env), publics, accessors); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return env, publics, accessors, nil
+}
+
+// declareMembers runs the given members of a class into the environment of
+// sc, recording whether each of them is public. Getters and setters go to
+// accessors instead, replacing both accessors of an inherited property.
+func (m *machine) declareMembers(members []compiler.Member, sc *scope, publics map[string]bool, accessors map[string]values.Accessor) *errors.RuntimeError {
+	redefined := map[string]bool{}
+	for _, member := range members {
+		if member.Accessor != "" {
+			if !redefined[member.Name] {
+				accessors[member.Name] = values.Accessor{}
+				redefined[member.Name] = true
+			}
+			accessors[member.Name] = accessors[member.Name].With(member.Accessor, makeClosure(member.Method, sc))
+			continue
+		}
+
+		var value shared.RuntimeValue
+		switch {
+		case member.Method != nil:
+			value = makeClosure(member.Method, sc)
+		case member.Value != nil:
+			result, err := m.run(member.Value, sc, nil)
+			if err != nil {
+				return err
+			}
+			value = *result
+		default:
+			value = values.MK_NIL()
+		}
+
+		if _, err := sc.env.DeclareVar(member.Name, value, false); err != nil {
+			return err
+		}
+		publics[member.Name] = member.IsPublic
+		delete(accessors, member.Name)
+	}
+	return nil
+}
+
+// instanceWithAccessors attaches getters and setters to a class instance
+func instanceWithAccessors(instance shared.RuntimeValue, accessors map[string]values.Accessor) shared.RuntimeValue {
+	instanceVal := instance.Value.(values.ClassInstanceValue)
+	instanceVal.Accessors = accessors
+	instance.Value = instanceVal
+	return instance
+}
+
+// runConstructor invokes the constructor of a class against an environment
+// produced by buildClassScope, walking up to the nearest ancestor defining one.
+func (m *machine) runConstructor(classVal *values.ClassValue, env *environment.Environment, args []*shared.RuntimeValue, site compiler.Site) *errors.RuntimeError {
+	for classVal.Constructor == nil && classVal.Parent != nil {
+		classVal = classVal.Parent
+		env = env.Parent
+	}
+
+	if classVal.Constructor == nil {
+		return &errors.RuntimeError{
+			Message: "Class has no constructor.",
+		}
+	}
+
+	payload, err := payloadOf(classVal)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.invoke(payload.class.Constructor, classScope(payload, env), args, site)
+	if isControlFlow(err, errors.ICP_Return) {
+		return &errors.RuntimeError{
+			Message: "Constructor cannot return a value.",
+		}
+	}
+	return strayControlFlow(err)
+}
+
+func (m *machine) superCall(ref *compiler.Ref, args []*shared.RuntimeValue, sc *scope, site compiler.Site) (*shared.RuntimeValue, *errors.RuntimeError) {
+	super, err := sc.lookup(ref)
+	if err != nil || super.Type != shared.ClassInstance {
+		return nil, &errors.RuntimeError{
+			Message: "`super` can only be called inside a class that extends another class.",
+		}
+	}
+
+	parent := super.Value.(values.ClassInstanceValue)
+	if err := m.runConstructor(&parent.Class, parent.Data, args, site); err != nil {
+		return nil, err
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
@@ -0,0 +1,19 @@
+package vm
+
+import (
+	"github.com/dev-kas/virtlang-go/v4/evaluator"
+)
+
+// interruptInterval is the number of instructions executed between two checks
+// of the deadline and the context
+const interruptInterval = 1024
+
+// machine tracks the resources used by a run, charging its budget one step
+// per instruction executed
+type machine struct {
+	*evaluator.Budget
+}
+
+func newMachine(opts evaluator.Options) *machine {
+	return &machine{Budget: evaluator.NewBudget(opts, "executed instructions", interruptInterval)}
+}
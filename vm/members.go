@@ -0,0 +1,362 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/compiler"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/evaluator"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// getMember reads a member of an object, array, class instance or class, the
+// latter exposing its static members. A nil key reads the member called name.
+// Getters are called from site.
+func (m *machine) getMember(object *shared.RuntimeValue, key *shared.RuntimeValue, name string, sc *scope, site compiler.Site) (*shared.RuntimeValue, *errors.RuntimeError) {
+	switch object.Type {
+	case shared.Object:
+		if key != nil {
+			switch v := key.Value.(type) {
+			case string:
+				name = v
+			case int:
+				name = fmt.Sprintf("%v", v)
+			default:
+				return nil, &errors.RuntimeError{
+					Message: fmt.Sprintf("Invalid property key type: %T", key.Value),
+				}
+			}
+		}
+
+		if value := object.Value.(map[string]*shared.RuntimeValue)[name]; value != nil {
+			return value, nil
+		}
+
+	case shared.Array:
+		if key == nil {
+			return nil, &errors.RuntimeError{
+				Message: fmt.Sprintf("Cannot access property of array by non-number (attempting to access properties by %v).", ast.IdentifierNode),
+			}
+		}
+		if key.Type != shared.Number {
+			return nil, &errors.RuntimeError{
+				Message: fmt.Sprintf("Cannot access property of array by non-number (attempting to access properties by %v).", shared.Stringify(key.Type)),
+			}
+		}
+
+		index := int(key.Value.(float64))
+		if elements := object.Value.([]shared.RuntimeValue); index >= 0 && index < len(elements) {
+			return &elements[index], nil
+		}
+
+	case shared.ClassInstance, shared.Class:
+		var publics map[string]bool
+		var data *environment.Environment
+		var accessors map[string]values.Accessor
+		if object.Type == shared.ClassInstance {
+			instance := object.Value.(values.ClassInstanceValue)
+			publics, data, accessors = instance.Publics, instance.Data, instance.Accessors
+		} else {
+			class := object.Value.(values.ClassValue)
+			publics, data = class.StaticPublics, class.Statics
+		}
+
+		if key != nil {
+			if key.Type != shared.String {
+				return nil, &errors.RuntimeError{
+					Message: fmt.Sprintf("Cannot access property of class instance by non-string (attempting to access properties by %v).", shared.Stringify(key.Type)),
+				}
+			}
+			name = key.Value.(string)
+		}
+
+		if accessor, ok := accessors[name]; ok {
+			return m.callGetter(accessor, sc, site)
+		}
+
+		if publics[name] {
+			value, err := data.LookupVar(name)
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				return value, nil
+			}
+		}
+
+	default:
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot access property of non-object or non-array (attempting to access properties of %v).", shared.Stringify(object.Type)),
+		}
+	}
+
+	nilValue := values.MK_NIL()
+	return &nilValue, nil
+}
+
+func checkAssignable(object *shared.RuntimeValue) *errors.RuntimeError {
+	if object.Type != shared.Object && object.Type != shared.Array && object.Type != shared.ClassInstance && object.Type != shared.Class {
+		return &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot access property of non-object (attempting to access properties of %v).", shared.Stringify(object.Type)),
+		}
+	}
+	return nil
+}
+
+// assignedInstance returns the class instance whose members an assignment to
+// a member of object updates, the static members of a class being viewed as
+// an instance
+func assignedInstance(object *shared.RuntimeValue) (values.ClassInstanceValue, bool) {
+	switch object.Type {
+	case shared.ClassInstance:
+		return object.Value.(values.ClassInstanceValue), true
+	case shared.Class:
+		return evaluator.StaticMembers(object.Value.(values.ClassValue)), true
+	}
+	return values.ClassInstanceValue{}, false
+}
+
+// instanceKey checks the key of a class instance member
+func instanceKey(key *shared.RuntimeValue) (string, *errors.RuntimeError) {
+	if key.Type != shared.String {
+		return "", &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot access property of class instance by non-string (attempting to access properties by %v).", shared.Stringify(key.Type)),
+		}
+	}
+	return key.Value.(string), nil
+}
+
+// peekMember reads the member a compound assignment updates
+func (m *machine) peekMember(object *shared.RuntimeValue, name string, sc *scope, site compiler.Site) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if instance, ok := assignedInstance(object); ok {
+		return m.peekInstanceMember(instance, name, sc, site)
+	}
+
+	if object.Type != shared.Object {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot assign to array using non-number index (attempted to use %v).", shared.Stringify(shared.String)),
+		}
+	}
+
+	if value, ok := object.Value.(map[string]*shared.RuntimeValue)[name]; ok {
+		return value, nil
+	}
+	nilValue := values.MK_NIL()
+	return &nilValue, nil
+}
+
+// peekIndex reads the member a compound assignment updates by key, checking
+// the key the way setIndex does. Array elements are copied, as setIndex
+// overwrites them in place.
+func (m *machine) peekIndex(object, key *shared.RuntimeValue, sc *scope, site compiler.Site) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if instance, ok := assignedInstance(object); ok {
+		name, err := instanceKey(key)
+		if err != nil {
+			return nil, err
+		}
+		return m.peekInstanceMember(instance, name, sc, site)
+	}
+
+	if object.Type == shared.Object {
+		name, ok := key.Value.(string)
+		if !ok {
+			return nil, &errors.RuntimeError{
+				Message: fmt.Sprintf("Invalid property key type: %T", key.Value),
+			}
+		}
+		if value, ok := object.Value.(map[string]*shared.RuntimeValue)[name]; ok {
+			return value, nil
+		}
+		nilValue := values.MK_NIL()
+		return &nilValue, nil
+	}
+
+	if key.Type != shared.Number {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot assign to array using non-number index (attempted to use %v).", shared.Stringify(key.Type)),
+		}
+	}
+
+	index := int(key.Value.(float64))
+	if index < 0 {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Index out of bounds: %d", index),
+		}
+	}
+
+	value := values.MK_NIL()
+	if elements := object.Value.([]shared.RuntimeValue); index < len(elements) {
+		value = elements[index]
+	}
+	return &value, nil
+}
+
+func (m *machine) setMember(object *shared.RuntimeValue, name string, value *shared.RuntimeValue, sc *scope, site compiler.Site) *errors.RuntimeError {
+	if instance, ok := assignedInstance(object); ok {
+		return m.setInstanceMember(instance, name, value, sc, site)
+	}
+
+	if object.Type != shared.Object {
+		return &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot assign to array using non-number index (attempted to use %v).", shared.Stringify(shared.String)),
+		}
+	}
+
+	object.Value.(map[string]*shared.RuntimeValue)[name] = value
+	return nil
+}
+
+// setIndex writes a member by key. Arrays are updated in place, and the
+// variable ref holding the array, if any, is reassigned to it.
+func (m *machine) setIndex(object, key, value *shared.RuntimeValue, ref *compiler.Ref, sc *scope, site compiler.Site) *errors.RuntimeError {
+	if instance, ok := assignedInstance(object); ok {
+		name, err := instanceKey(key)
+		if err != nil {
+			return err
+		}
+		return m.setInstanceMember(instance, name, value, sc, site)
+	}
+
+	if object.Type == shared.Object {
+		name, ok := key.Value.(string)
+		if !ok {
+			return &errors.RuntimeError{
+				Message: fmt.Sprintf("Invalid property key type: %T", key.Value),
+			}
+		}
+		object.Value.(map[string]*shared.RuntimeValue)[name] = value
+		return nil
+	}
+
+	if key.Type != shared.Number {
+		return &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot assign to array using non-number index (attempted to use %v).", shared.Stringify(key.Type)),
+		}
+	}
+
+	index := int(key.Value.(float64))
+	if index < 0 {
+		return &errors.RuntimeError{
+			Message: fmt.Sprintf("Index out of bounds: %d", index),
+		}
+	}
+
+	elements := object.Value.([]shared.RuntimeValue)
+	if index >= len(elements) {
+		extended := make([]shared.RuntimeValue, index+1)
+		copy(extended, elements)
+		for i := len(elements); i <= index; i++ {
+			extended[i] = values.MK_NIL()
+		}
+		elements = extended
+	}
+	elements[index] = *value
+	object.Value = elements
+
+	if ref != nil {
+		if _, err := sc.assign(ref, *object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// peekInstanceMember reads the member of a class instance an assignment
+// updates, through its getter when it has one
+func (m *machine) peekInstanceMember(instance values.ClassInstanceValue, name string, sc *scope, site compiler.Site) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if accessor, ok := instance.Accessors[name]; ok {
+		return m.callGetter(accessor, sc, site)
+	}
+	if err := evaluator.CheckMemberAssignable(instance, name); err != nil {
+		return nil, err
+	}
+	return instance.Data.LookupVar(name)
+}
+
+// setInstanceMember writes a member of a class instance, through its setter
+// when it has one
+func (m *machine) setInstanceMember(instance values.ClassInstanceValue, name string, value *shared.RuntimeValue, sc *scope, site compiler.Site) *errors.RuntimeError {
+	if err := evaluator.CheckMemberAssignable(instance, name); err != nil {
+		return err
+	}
+	if accessor, ok := instance.Accessors[name]; ok {
+		_, err := m.call(accessor.Set, []*shared.RuntimeValue{value}, sc, site)
+		return err
+	}
+	_, err := instance.Data.AssignVar(name, *value)
+	return err
+}
+
+// callGetter reads a property through its getter, a property with only a
+// setter reading as nil
+func (m *machine) callGetter(accessor values.Accessor, sc *scope, site compiler.Site) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if accessor.Get == nil {
+		nilValue := values.MK_NIL()
+		return &nilValue, nil
+	}
+	return m.call(accessor.Get, nil, sc, site)
+}
+
+func checkDestructurable(value *shared.RuntimeValue, expected shared.ValueType, pattern string) *errors.RuntimeError {
+	if value.Type != expected {
+		return &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot destructure value of type %s with an %s pattern", shared.Stringify(value.Type), pattern),
+		}
+	}
+	return nil
+}
+
+// destructuredArray returns the elements an array pattern binds: those of an
+// array, or the characters of a string
+func destructuredArray(value *shared.RuntimeValue) (shared.RuntimeValue, *errors.RuntimeError) {
+	switch value.Type {
+	case shared.Array:
+		return *value, nil
+	case shared.String:
+		chars := []shared.RuntimeValue{}
+		for _, char := range value.Value.(string) {
+			chars = append(chars, values.MK_STRING(string(char)))
+		}
+		return values.MK_ARRAY(chars), nil
+	default:
+		return shared.RuntimeValue{}, checkDestructurable(value, shared.Array, "array")
+	}
+}
+
+func restProperties(properties map[string]*shared.RuntimeValue, assigned []string) shared.RuntimeValue {
+	rest := map[string]*shared.RuntimeValue{}
+	for key, value := range properties {
+		rest[key] = value
+	}
+	for _, key := range assigned {
+		delete(rest, key)
+	}
+	return values.MK_OBJECT(rest)
+}
+
+func caughtValue(err *errors.RuntimeError) shared.RuntimeValue {
+	if err.Thrown != nil {
+		return *err.Thrown
+	}
+
+	message := values.MK_STRING(err.Message)
+	frames := make([]shared.RuntimeValue, 0, len(err.Frames))
+	for _, frame := range err.Frames {
+		name := values.MK_STRING(frame.Name)
+		line := values.MK_NUMBER(float64(frame.Line))
+		file := values.MK_STRING(frame.Filename)
+		frames = append(frames, values.MK_OBJECT(map[string]*shared.RuntimeValue{
+			"name": &name,
+			"line": &line,
+			"file": &file,
+		}))
+	}
+	stack := values.MK_ARRAY(frames)
+	return values.MK_OBJECT(map[string]*shared.RuntimeValue{
+		"message": &message,
+		"stack":   &stack,
+	})
+}
@@ -0,0 +1,121 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/dev-kas/virtlang-go/v4/compiler"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+)
+
+// scope is a runtime scope. Scopes laid out by the compiler keep their
+// variables in slots, the global scope and class scopes wrap an environment.
+type scope struct {
+	parent *scope
+	layout *compiler.Scope
+	slots  []binding
+	env    *environment.Environment
+}
+
+// binding is a slot, a nil value means the variable was not declared yet
+type binding struct {
+	value    *shared.RuntimeValue
+	constant bool
+}
+
+func newScope(parent *scope, layout *compiler.Scope) *scope {
+	return &scope{parent: parent, layout: layout, slots: make([]binding, len(layout.Names))}
+}
+
+// fork copies the bindings of a scope into a fresh one, so closures created
+// during a loop iteration keep that iteration's values
+func (s *scope) fork() *scope {
+	next := newScope(s.parent, s.layout)
+	for i, b := range s.slots {
+		if b.value != nil {
+			value := *b.value
+			next.slots[i] = binding{value: &value, constant: b.constant}
+		}
+	}
+	return next
+}
+
+func (s *scope) up(depth int) *scope {
+	for ; depth > 0; depth-- {
+		s = s.parent
+	}
+	return s
+}
+
+// environment returns the nearest environment backed scope
+func (s *scope) environment() *environment.Environment {
+	for ; s != nil; s = s.parent {
+		if s.env != nil {
+			return s.env
+		}
+	}
+	return nil
+}
+
+func (s *scope) declare(slot int, value shared.RuntimeValue, constant bool) (*shared.RuntimeValue, *errors.RuntimeError) {
+	if s.slots[slot].value != nil {
+		return nil, &errors.RuntimeError{
+			Message: fmt.Sprintf("Cannot redeclare variable `%s`", s.layout.Names[slot]),
+		}
+	}
+
+	s.slots[slot] = binding{value: &value, constant: constant}
+	return s.slots[slot].value, nil
+}
+
+// lookup finds the first candidate of a reference where the variable is
+// declared
+func (s *scope) lookup(ref *compiler.Ref) (*shared.RuntimeValue, *errors.RuntimeError) {
+	for _, location := range ref.Candidates {
+		target := s.up(location.Depth)
+		if location.Slot == compiler.Dynamic {
+			if value, err := target.env.LookupVar(ref.Name); err == nil {
+				return value, nil
+			}
+			continue
+		}
+		if b := target.slots[location.Slot]; b.value != nil {
+			return b.value, nil
+		}
+	}
+
+	return nil, unresolved(ref.Name)
+}
+
+func (s *scope) assign(ref *compiler.Ref, value shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
+	for _, location := range ref.Candidates {
+		target := s.up(location.Depth)
+		if location.Slot == compiler.Dynamic {
+			if _, err := target.env.Resolve(ref.Name); err == nil {
+				return target.env.AssignVar(ref.Name, value)
+			}
+			continue
+		}
+
+		b := &target.slots[location.Slot]
+		if b.value == nil {
+			continue
+		}
+		if b.constant {
+			return nil, &errors.RuntimeError{
+				Message: fmt.Sprintf("Cannot reassign to constant variable `%s`", ref.Name),
+			}
+		}
+		b.value = &value
+		return b.value, nil
+	}
+
+	return nil, unresolved(ref.Name)
+}
+
+func unresolved(name string) *errors.RuntimeError {
+	return &errors.RuntimeError{
+		Message: fmt.Sprintf("Cannot resolve variable `%s`", name),
+	}
+}
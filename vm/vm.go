@@ -0,0 +1,649 @@
+// Package vm runs the bytecode produced by the compiler package. It is an
+// alternative to the tree-walking evaluator with the same semantics: programs
+// produce the same values and errors and leave the same variables behind.
+//
+// The debugger is not supported, run programs with the evaluator to debug
+// them.
+package vm
+
+import (
+	"strings"
+
+	"github.com/dev-kas/virtlang-go/v4/ast"
+	"github.com/dev-kas/virtlang-go/v4/compiler"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/evaluator"
+	"github.com/dev-kas/virtlang-go/v4/helpers"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+)
+
+// Types of the values that only live on the stack
+const (
+	missingValue  shared.ValueType = -(iota + 1) // Member absent from a destructured value
+	pendingValue                                 // Completion a finally block resumes, wrapping a *errors.RuntimeError
+	iteratorValue                                // Iterator of a for ... in / for ... of loop, wrapping an *iterator
+)
+
+type iterator struct {
+	items []shared.RuntimeValue
+	next  int
+}
+
+type frame struct {
+	fn       *compiler.Function
+	ip       int
+	stack    []*shared.RuntimeValue
+	scope    *scope
+	handlers []handler
+	result   *shared.RuntimeValue
+	caught   *errors.RuntimeError   // Error caught by the last catch handler
+	args     []*shared.RuntimeValue // Arguments of the call running the function
+}
+
+// handler is a loop, catch or finally block protecting part of the code
+type handler struct {
+	kind     compiler.Opcode
+	target   int
+	next     int    // Continue target of loops
+	label    string // Label of loops, empty when unlabeled
+	stackLen int
+	scope    *scope
+}
+
+// Run executes a compiled program in env, like evaluator.Evaluate does for
+// the program it was compiled from.
+func Run(fn *compiler.Function, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	return RunWithOptions(fn, env, evaluator.Options{})
+}
+
+// RunWithOptions executes a compiled program like Run within the limits of
+// opts, like evaluator.EvaluateWithOptions does. MaxNodes limits the number
+// of instructions executed.
+func RunWithOptions(fn *compiler.Function, env *environment.Environment, opts evaluator.Options) (*shared.RuntimeValue, *errors.RuntimeError) {
+	m := newMachine(opts)
+	result, err := m.run(fn, &scope{env: env}, nil)
+	if err != nil {
+		if isControlFlow(err, errors.ICP_Return) {
+			return err.InternalCommunicationProtocol.RValue, nil
+		}
+		return nil, strayControlFlow(err)
+	}
+	return result, nil
+}
+
+func isControlFlow(err *errors.RuntimeError, kind errors.InternalCommunicationProtocolTypes) bool {
+	return err != nil && err.InternalCommunicationProtocol != nil && err.InternalCommunicationProtocol.Type == kind
+}
+
+// strayControlFlow turns a `break` or `continue` that reached the end of a
+// function or program without meeting its loop into a plain error
+func strayControlFlow(err *errors.RuntimeError) *errors.RuntimeError {
+	if !isControlFlow(err, errors.ICP_Break) && !isControlFlow(err, errors.ICP_Continue) {
+		return err
+	}
+	return &errors.RuntimeError{
+		Message:  err.Message,
+		Filename: err.Filename,
+		Line:     err.Line,
+	}
+}
+
+// isControlFlowFor reports whether err is a `break` or `continue` aimed at a
+// statement with the given label. Unlabeled ones aim at the innermost one
+func isControlFlowFor(err *errors.RuntimeError, kind errors.InternalCommunicationProtocolTypes, label string) bool {
+	return isControlFlow(err, kind) && (err.InternalCommunicationProtocol.Label == "" || err.InternalCommunicationProtocol.Label == label)
+}
+
+// label decodes a label encoded by the compiler, empty when there is none
+func label(fn *compiler.Function, encoded int) string {
+	if encoded == 0 {
+		return ""
+	}
+	return fn.Names[encoded-1]
+}
+
+func (f *frame) push(value *shared.RuntimeValue) {
+	f.stack = append(f.stack, value)
+}
+
+func (f *frame) pop() *shared.RuntimeValue {
+	value := f.stack[len(f.stack)-1]
+	f.stack = f.stack[:len(f.stack)-1]
+	return value
+}
+
+func (f *frame) peek() *shared.RuntimeValue {
+	return f.stack[len(f.stack)-1]
+}
+
+// popN pops the n values on top of the stack, bottom first
+func (f *frame) popN(n int) []*shared.RuntimeValue {
+	items := make([]*shared.RuntimeValue, n)
+	copy(items, f.stack[len(f.stack)-n:])
+	f.stack = f.stack[:len(f.stack)-n]
+	return items
+}
+
+func (f *frame) hasFinally() bool {
+	for _, h := range f.handlers {
+		if h.kind == compiler.OpPushFinally {
+			return true
+		}
+	}
+	return false
+}
+
+// unwind hands an error to the innermost handler accepting it, reporting
+// whether one did
+func (f *frame) unwind(err *errors.RuntimeError) bool {
+	for len(f.handlers) > 0 {
+		h := f.handlers[len(f.handlers)-1]
+		f.handlers = f.handlers[:len(f.handlers)-1]
+
+		target := -1
+		switch h.kind {
+		case compiler.OpPushLoop:
+			if isControlFlowFor(err, errors.ICP_Break, h.label) {
+				target = h.target
+			} else if isControlFlowFor(err, errors.ICP_Continue, h.label) {
+				target = h.next
+			}
+		case compiler.OpPushSwitch:
+			if isControlFlowFor(err, errors.ICP_Break, "") {
+				target = h.target
+			}
+		case compiler.OpPushCatch:
+			if err.InternalCommunicationProtocol == nil && err.Catchable() {
+				target = h.target
+			}
+		case compiler.OpPushFinally:
+			target = h.target
+		}
+		if target < 0 {
+			continue
+		}
+
+		f.stack = f.stack[:h.stackLen]
+		f.scope = h.scope
+		f.ip = target
+		switch h.kind {
+		case compiler.OpPushCatch:
+			f.caught = err
+		case compiler.OpPushFinally:
+			nilValue := values.MK_NIL()
+			f.push(&nilValue)
+			f.push(&shared.RuntimeValue{Type: pendingValue, Value: err})
+		}
+		return true
+	}
+	return false
+}
+
+func (m *machine) run(fn *compiler.Function, sc *scope, args []*shared.RuntimeValue) (*shared.RuntimeValue, *errors.RuntimeError) {
+	f := &frame{fn: fn, scope: sc, args: args}
+	code := fn.Code
+
+	for f.ip < len(code) {
+		start := f.ip
+		if err := m.Step(); err != nil {
+			locate(err, fn, start)
+			if !f.unwind(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		ins := code[f.ip]
+		f.ip++
+		arg := ins.Arg()
+
+		var err *errors.RuntimeError
+
+		switch ins.Op() {
+		case compiler.OpConstant:
+			value := fn.Constants[arg]
+			f.push(&value)
+
+		case compiler.OpNil:
+			value := values.MK_NIL()
+			f.push(&value)
+
+		case compiler.OpArray:
+			items := f.popN(arg)
+			elements := make([]shared.RuntimeValue, len(items))
+			for i, item := range items {
+				elements[i] = *item
+			}
+			array := values.MK_ARRAY(elements)
+			f.push(&array)
+
+		case compiler.OpObject:
+			keys := fn.Keys[arg]
+			items := f.popN(len(keys))
+			properties := map[string]*shared.RuntimeValue{}
+			for i, key := range keys {
+				value := *items[i]
+				properties[key] = &value
+			}
+			f.push(&shared.RuntimeValue{Type: shared.Object, Value: properties})
+
+		case compiler.OpSpread:
+			var items []shared.RuntimeValue
+			if items, err = evaluator.IterationValues(f.pop()); err == nil {
+				array := f.pop()
+				elements := append(append([]shared.RuntimeValue{}, array.Value.([]shared.RuntimeValue)...), items...)
+				value := values.MK_ARRAY(elements)
+				f.push(&value)
+			}
+
+		case compiler.OpMerge:
+			value := f.pop()
+			err = evaluator.SpreadProperties(f.peek().Value.(map[string]*shared.RuntimeValue), value)
+
+		case compiler.OpClosure:
+			value := makeClosure(fn.Functions[arg], f.scope)
+			f.push(&value)
+
+		case compiler.OpClass:
+			class := fn.Classes[arg]
+			var parent *shared.RuntimeValue
+			if class.Extends {
+				parent = f.pop()
+			}
+			var value shared.RuntimeValue
+			value, err = m.makeClass(class, parent, f.scope)
+			if err == nil {
+				f.push(&value)
+			}
+
+		case compiler.OpTemplate:
+			var sb strings.Builder
+			for _, part := range f.popN(arg) {
+				sb.WriteString(helpers.ToString(part))
+			}
+			value := values.MK_STRING(sb.String())
+			f.push(&value)
+
+		case compiler.OpPop:
+			f.pop()
+
+		case compiler.OpDup:
+			f.push(f.peek())
+
+		case compiler.OpBury:
+			top := len(f.stack) - 1
+			value := f.stack[top]
+			copy(f.stack[top-arg+1:], f.stack[top-arg:top])
+			f.stack[top-arg] = value
+
+		case compiler.OpGetVar:
+			var value *shared.RuntimeValue
+			if value, err = f.scope.lookup(&fn.Refs[arg]); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpSetVar:
+			var value *shared.RuntimeValue
+			if value, err = f.scope.assign(&fn.Refs[arg], *f.pop()); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpDeclareVar, compiler.OpDeclareConst:
+			var value *shared.RuntimeValue
+			if value, err = f.scope.declare(arg, *f.pop(), ins.Op() == compiler.OpDeclareConst); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpDeclareName, compiler.OpDeclareNameConst:
+			var value *shared.RuntimeValue
+			if value, err = f.scope.environment().DeclareVar(fn.Names[arg], *f.pop(), ins.Op() == compiler.OpDeclareNameConst); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpPushScope:
+			f.scope = newScope(f.scope, fn.Scopes[arg])
+
+		case compiler.OpPopScope:
+			f.scope = f.scope.parent
+
+		case compiler.OpForkScope:
+			f.scope = f.scope.fork()
+
+		case compiler.OpJump:
+			f.ip = arg
+
+		case compiler.OpJumpIfFalsy:
+			if !helpers.IsTruthy(f.pop()) {
+				f.ip = arg
+			}
+
+		case compiler.OpJumpIfNotTrue:
+			if cond := f.pop(); cond.Type != shared.Boolean || !cond.Value.(bool) {
+				f.ip = arg
+			}
+
+		case compiler.OpJumpIfFalsyKeep:
+			if !helpers.IsTruthy(f.peek()) {
+				f.ip = arg
+			} else {
+				f.pop()
+			}
+
+		case compiler.OpJumpIfTruthyKeep:
+			if helpers.IsTruthy(f.peek()) {
+				f.ip = arg
+			} else {
+				f.pop()
+			}
+
+		case compiler.OpJumpIfNotNilKeep:
+			if lhs := f.peek(); lhs.Type != shared.Nil || lhs.Value != nil {
+				f.ip = arg
+			} else {
+				f.pop()
+			}
+
+		case compiler.OpJumpIfNil:
+			if f.peek().Type == shared.Nil {
+				f.ip = arg
+			}
+
+		case compiler.OpNot:
+			value := values.MK_BOOL(!helpers.IsTruthy(f.pop()))
+			f.push(&value)
+
+		case compiler.OpUnary:
+			var value *shared.RuntimeValue
+			if value, err = evaluator.UnaryOp(ast.UnaryOperator(fn.Names[arg]), f.pop()); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpUpdate:
+			var value *shared.RuntimeValue
+			if value, err = evaluator.UpdateOp(ast.UpdateOperator(fn.Names[arg]), f.pop()); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpBinary:
+			rhs, lhs := f.pop(), f.pop()
+			var value *shared.RuntimeValue
+			if value, err = evaluator.BinaryOp(ast.BinaryOperator(fn.Names[arg]), lhs, rhs); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpCompare:
+			rhs, lhs := f.pop(), f.pop()
+			var value *shared.RuntimeValue
+			if value, err = evaluator.CompareOp(ast.CompareOperator(fn.Names[arg]), lhs, rhs); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpGetMember:
+			site := fn.Sites[code[f.ip]]
+			f.ip++
+			var value *shared.RuntimeValue
+			if value, err = m.getMember(f.pop(), nil, fn.Names[arg], f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpGetIndex:
+			site := fn.Sites[code[f.ip]]
+			f.ip++
+			key, object := f.pop(), f.pop()
+			var value *shared.RuntimeValue
+			if value, err = m.getMember(object, key, "", f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpCheckAssignable:
+			err = checkAssignable(f.peek())
+
+		case compiler.OpPeekMember:
+			site := fn.Sites[code[f.ip]]
+			f.ip++
+			var value *shared.RuntimeValue
+			if value, err = m.peekMember(f.peek(), fn.Names[arg], f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpPeekIndex:
+			site := fn.Sites[code[f.ip]]
+			f.ip++
+			var value *shared.RuntimeValue
+			if value, err = m.peekIndex(f.stack[len(f.stack)-2], f.peek(), f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpSetMember:
+			site := fn.Sites[code[f.ip]]
+			f.ip++
+			value, object := f.pop(), f.pop()
+			if err = m.setMember(object, fn.Names[arg], value, f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpSetIndex:
+			site := fn.Sites[code[f.ip]]
+			f.ip++
+			value, key, object := f.pop(), f.pop(), f.pop()
+			var ref *compiler.Ref
+			if arg > 0 {
+				ref = &fn.Refs[arg-1]
+			}
+			if err = m.setIndex(object, key, value, ref, f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpCall:
+			site := fn.Sites[code[f.ip]]
+			f.ip++
+			callee := f.pop()
+			args := f.popN(arg)
+			var value *shared.RuntimeValue
+			if value, err = m.call(callee, args, f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpSuperCall:
+			ref := &fn.Refs[code[f.ip]]
+			site := fn.Sites[code[f.ip+1]]
+			f.ip += 2
+			args := f.popN(arg)
+			var value *shared.RuntimeValue
+			if value, err = m.superCall(ref, args, f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpCallSpread:
+			site := fn.Sites[code[f.ip]]
+			f.ip++
+			callee := f.pop()
+			var value *shared.RuntimeValue
+			if value, err = m.call(callee, spreadArgs(f.pop()), f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpSuperCallSpread:
+			ref := &fn.Refs[code[f.ip]]
+			site := fn.Sites[code[f.ip+1]]
+			f.ip += 2
+			var value *shared.RuntimeValue
+			if value, err = m.superCall(ref, spreadArgs(f.pop()), f.scope, site); err == nil {
+				f.push(value)
+			}
+
+		case compiler.OpArg:
+			if arg < len(f.args) && f.args[arg].Type != shared.Nil {
+				f.push(f.args[arg])
+			} else {
+				f.push(&shared.RuntimeValue{Type: missingValue})
+			}
+
+		case compiler.OpRestArgs:
+			rest := restArgs(f.args, arg)
+			f.push(&rest)
+
+		case compiler.OpReturn:
+			value := f.pop()
+			// Finally blocks run before the function is left, constructors
+			// turn returns into errors at the call boundary
+			if !fn.IsConstructor && !f.hasFinally() {
+				return value, nil
+			}
+			err = &errors.RuntimeError{
+				Message: "<RETURN STATEMENT>",
+				InternalCommunicationProtocol: &errors.InternalCommunicationProtocol{
+					Type:   errors.ICP_Return,
+					RValue: value,
+				},
+			}
+
+		case compiler.OpBreak:
+			err = &errors.RuntimeError{
+				Message: "`break` statement used outside of a loop context.",
+				InternalCommunicationProtocol: &errors.InternalCommunicationProtocol{
+					Type:  errors.ICP_Break,
+					Label: label(fn, arg),
+				},
+			}
+
+		case compiler.OpContinue:
+			err = &errors.RuntimeError{
+				Message: "`continue` statement used outside of a loop context.",
+				InternalCommunicationProtocol: &errors.InternalCommunicationProtocol{
+					Type:  errors.ICP_Continue,
+					Label: label(fn, arg),
+				},
+			}
+
+		case compiler.OpThrow:
+			value := f.pop()
+			err = &errors.RuntimeError{
+				Message: evaluator.ThrownMessage(value),
+				Thrown:  value,
+			}
+
+		case compiler.OpSetResult:
+			f.result = f.pop()
+
+		case compiler.OpReturnResult:
+			result := values.MK_NIL()
+			if f.result != nil {
+				result = *f.result
+			}
+			return &result, nil
+
+		case compiler.OpFail:
+			err = &errors.RuntimeError{Message: fn.Names[arg]}
+
+		case compiler.OpPushLoop:
+			f.handlers = append(f.handlers, handler{kind: compiler.OpPushLoop, target: arg, next: int(code[f.ip]), label: label(fn, int(code[f.ip+1])), stackLen: len(f.stack), scope: f.scope})
+			f.ip += 2
+
+		case compiler.OpPushSwitch, compiler.OpPushCatch, compiler.OpPushFinally:
+			f.handlers = append(f.handlers, handler{kind: ins.Op(), target: arg, stackLen: len(f.stack), scope: f.scope})
+
+		case compiler.OpPopHandler:
+			f.handlers = f.handlers[:len(f.handlers)-1]
+
+		case compiler.OpCaught:
+			value := caughtValue(f.caught)
+			f.push(&value)
+
+		case compiler.OpEndFinally:
+			if pending := f.pop(); pending.Type == pendingValue {
+				err = pending.Value.(*errors.RuntimeError)
+			}
+
+		case compiler.OpIterKeys, compiler.OpIterValues:
+			var items []shared.RuntimeValue
+			if ins.Op() == compiler.OpIterKeys {
+				items, err = evaluator.IterationKeys(f.pop())
+			} else {
+				items, err = evaluator.IterationValues(f.pop())
+			}
+			if err == nil {
+				f.push(&shared.RuntimeValue{Type: iteratorValue, Value: &iterator{items: items}})
+			}
+
+		case compiler.OpIterNext:
+			it := f.peek().Value.(*iterator)
+			if it.next >= len(it.items) {
+				f.ip = arg
+			} else {
+				f.push(&it.items[it.next])
+				it.next++
+			}
+
+		case compiler.OpDestructObject:
+			err = checkDestructurable(f.peek(), shared.Object, "object")
+
+		case compiler.OpDestructProp:
+			properties := f.peek().Value.(map[string]*shared.RuntimeValue)
+			if value, ok := properties[fn.Names[arg]]; ok && value.Type != shared.Nil {
+				f.push(value)
+			} else {
+				f.push(&shared.RuntimeValue{Type: missingValue})
+			}
+
+		case compiler.OpDestructObjectRest:
+			rest := restProperties(f.peek().Value.(map[string]*shared.RuntimeValue), fn.Keys[arg])
+			f.push(&rest)
+
+		case compiler.OpDestructArray:
+			var array shared.RuntimeValue
+			if array, err = destructuredArray(f.pop()); err == nil {
+				f.push(&array)
+			}
+
+		case compiler.OpDestructElem:
+			if elements := f.peek().Value.([]shared.RuntimeValue); arg < len(elements) {
+				f.push(&elements[arg])
+			} else {
+				f.push(&shared.RuntimeValue{Type: missingValue})
+			}
+
+		case compiler.OpDestructArrayRest:
+			rest := []shared.RuntimeValue{}
+			if elements := f.peek().Value.([]shared.RuntimeValue); arg < len(elements) {
+				rest = elements[arg:]
+			}
+			array := values.MK_ARRAY(rest)
+			f.push(&array)
+
+		case compiler.OpJumpIfPresent:
+			if f.peek().Type == missingValue {
+				f.pop()
+			} else {
+				f.ip = arg
+			}
+
+		case compiler.OpMatchPattern:
+			matches := values.MK_BOOL(evaluator.PatternMatches(fn.Shapes[arg], f.peek()))
+			f.push(&matches)
+		}
+
+		if err != nil {
+			locate(err, fn, start)
+			if !f.unwind(err) {
+				return nil, err
+			}
+		}
+	}
+
+	result := values.MK_NIL()
+	return &result, nil
+}
+
+// locate records where an error was raised, at the instruction at offset,
+// unless an instruction it went through first already did
+func locate(err *errors.RuntimeError, fn *compiler.Function, offset int) {
+	if err.Line != 0 {
+		return
+	}
+	if site, ok := fn.PositionAt(offset); ok {
+		err.Filename, err.Line = site.Filename, site.Line
+	}
+}
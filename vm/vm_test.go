@@ -0,0 +1,146 @@
+package vm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dev-kas/virtlang-go/v4/compiler"
+	"github.com/dev-kas/virtlang-go/v4/environment"
+	"github.com/dev-kas/virtlang-go/v4/errors"
+	"github.com/dev-kas/virtlang-go/v4/evaluator"
+	"github.com/dev-kas/virtlang-go/v4/internal/testhelpers"
+	"github.com/dev-kas/virtlang-go/v4/shared"
+	"github.com/dev-kas/virtlang-go/v4/values"
+	"github.com/dev-kas/virtlang-go/v4/vm"
+)
+
+func run(t *testing.T, src string, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+	t.Helper()
+	fn, err := compiler.Compile(testhelpers.MustParse(t, src))
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	return vm.Run(fn, env)
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		input  string
+		output float64
+	}{
+		{"fn fib(n) { if (n < 2) { return n } return fib(n - 1) + fib(n - 2) }\nfib(15)", 610},
+		{"let fns = []\nfor (let i = 0; i < 3; i = i + 1) { fn get() { return i }\n fns[i] = get }\nfns[0]() + fns[2]()", 2},
+		{"let n = 0\nwhile (n < 10) { n = n + 1\n try { if (n > 3) { break } } catch e {} }\nn", 4},
+		{"let x = 0\nfn f() { try { return 1 } finally { x = 5 } }\nlet r = f()\nr + x", 6},
+		{"class A { private s = 3 public constructor() {} public m() { return s } }\nclass B extends A { public constructor() { super() } public n() { return m() + 1 } }\nlet b = B()\nb.n()", 4},
+	}
+
+	for _, test := range tests {
+		result, err := run(t, test.input, environment.NewEnvironment(nil))
+		if err != nil {
+			t.Fatalf("input=%q: expected no error, got %v", test.input, err)
+		}
+		if result.Type != shared.Number || result.Value != test.output {
+			t.Errorf("input=%q: expected %v, got %v", test.input, test.output, result.Value)
+		}
+	}
+}
+
+func TestRunHostEnvironment(t *testing.T) {
+	env := environment.NewEnvironment(nil)
+	env.DeclareVar("double", values.MK_NATIVE_FN(func(args []shared.RuntimeValue, env *environment.Environment) (*shared.RuntimeValue, *errors.RuntimeError) {
+		result := values.MK_NUMBER(args[0].Value.(float64) * 2)
+		return &result, nil
+	}), true)
+
+	if _, err := run(t, "let x = double(21)", env); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	x, err := env.LookupVar("x")
+	if err != nil || x.Value != 42.0 {
+		t.Errorf("expected x to be 42, got %v (%v)", x, err)
+	}
+
+	if _, err := run(t, "double = 1", env); err == nil || err.Message != "Cannot reassign to constant variable `double`" {
+		t.Errorf("expected constant error, got %v", err)
+	}
+}
+
+func TestRunWithOptions(t *testing.T) {
+	tests := []struct {
+		input string
+		opts  evaluator.Options
+		kind  errors.RuntimeErrorKind
+	}{
+		{"while (1 == 1) {}", evaluator.Options{MaxNodes: 1000}, errors.NodeLimitExceeded},
+		{"while (1 == 1) {}", evaluator.Options{Deadline: time.Now().Add(10 * time.Millisecond)}, errors.DeadlineExceeded},
+		{"fn f() { f() }\nf()", evaluator.Options{MaxCallDepth: 50}, errors.CallDepthExceeded},
+		{"while (1 == 1) { try { while (1 == 1) {} } catch e {} finally { continue } }", evaluator.Options{MaxNodes: 1000}, errors.NodeLimitExceeded},
+	}
+
+	for _, test := range tests {
+		fn, synErr := compiler.Compile(testhelpers.MustParse(t, test.input))
+		if synErr != nil {
+			t.Fatalf("compile error: %v", synErr)
+		}
+		_, err := vm.RunWithOptions(fn, environment.NewEnvironment(nil), test.opts)
+		if err == nil || !err.IsLimit() || err.Catchable() != (test.kind == errors.CallDepthExceeded) || err.Kind != test.kind {
+			t.Errorf("input=%q: expected error kind %v, got %v", test.input, test.kind, err)
+		}
+	}
+}
+
+// benchmarkScript exercises calls, loops, arrays, objects and classes
+const benchmarkScript = `
+fn fib(n) {
+	if (n < 2) { return n }
+	return fib(n - 1) + fib(n - 2)
+}
+
+class Counter {
+	private n = 0
+	public add(k) { n += k }
+	public get value() { return n }
+}
+
+let squares = []
+let counter = Counter()
+for (let i = 0; i < 200; i++) {
+	squares[i] = i * i
+	counter.add(i)
+}
+
+let totals = { sum: 0, label: "" }
+for (const square of squares) {
+	totals.sum += square
+}
+totals.label = ` + "`${counter.value} ${totals.sum}`" + `
+fib(15)
+`
+
+// BenchmarkBackends compares the evaluator with the vm on the same script,
+// compiling it once for the vm
+func BenchmarkBackends(b *testing.B) {
+	program := testhelpers.MustParse(b, benchmarkScript)
+
+	b.Run("evaluator", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := evaluator.Evaluate(program, environment.NewEnvironment(nil), nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("vm", func(b *testing.B) {
+		fn, synErr := compiler.Compile(program)
+		if synErr != nil {
+			b.Fatal(synErr)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := vm.Run(fn, environment.NewEnvironment(nil)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}